@@ -5,9 +5,11 @@ package argue
 import (
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"regexp"
+	"strings"
 	"unicode"
 )
 
@@ -22,19 +24,56 @@ var (
 	ErrMissingPositionals = errors.New("argue: not enough positional arguments provided")
 	ErrMissingFlag        = errors.New("argue: a required flag is missing")
 	ErrUnknownFlag        = errors.New("argue: dispute found an unknown flag while parsing")
+	ErrConfigFile         = errors.New("argue: unable to load configuration file")
+
+	// Help and Version
+	ErrHelpRequested    = errors.New("argue: help was requested")
+	ErrVersionRequested = errors.New("argue: version was requested")
 
 	// Fact
 	ErrWrongType   = errors.New("argue: fact was not able to set a value due to mismatched types")
 	ErrNilValue    = errors.New("argue: nil was passed to a flag")
 	ErrInvalidType = errors.New("argue: invalid type passed to GetFactType. " +
-		"Options are *string, *bool, *int, *int64, *uint, *uint64, *float32, and *float64")
+		"Options are *string, *bool, *int, *int64, *uint, *uint64, *float32, *float64, " +
+		"and slices of those types other than *[]bool")
 )
 
 // Define regular expressions
 var (
-	flagReg = regexp.MustCompile(`^(-\S|--\S+)$`)
+	flagReg   = regexp.MustCompile(`^(-[^-\s]\S*|--\S+)$`)
+	numberReg = regexp.MustCompile(`^-(\d+\.?\d*|\.\d+)$`)
 )
 
+// splitFlagValue separates an argument in the form
+// of "--name=value" or "-n=value" into its flag and
+// value. The returned bool is true if a value was
+// attached to the flag, even if it was empty.
+func splitFlagValue(arg string) (string, string, bool) {
+	i := strings.Index(arg, "=")
+	if i < 0 || !flagReg.MatchString(arg[:i]) {
+		return arg, "", false
+	}
+
+	return arg[:i], arg[i+1:], true
+}
+
+// SplitTerminator splits arguments at the first
+// "--" end-of-options terminator, returning the
+// arguments before and after it. The terminator
+// itself is not included in either slice. The
+// returned bool is true if the terminator was found,
+// which allows wrappers to forward the tail of the
+// arguments verbatim.
+func SplitTerminator(arguments []string) ([]string, []string, bool) {
+	for i, arg := range arguments {
+		if arg == "--" {
+			return arguments[:i], arguments[i+1:], true
+		}
+	}
+
+	return arguments, nil, false
+}
+
 func binaryName() string {
 	return filepath.Base(os.Args[0])
 }
@@ -52,8 +91,77 @@ func breakCammelCase(s string) string {
 	return broken
 }
 
-func printError(msg string) {
-	fmt.Printf("Error: %v\n", msg)
-	fmt.Printf("Run \"%v --help\" to see usage information\n", os.Args[0])
-	os.Exit(1)
+// console holds the writers and exit function that
+// Arguments and Lawyers use to communicate with the
+// user. The zero value uses the standard output for
+// usage and version information, the standard error
+// for errors, os.Exit, and the detected width of the
+// terminal.
+type console struct {
+	stdout io.Writer
+	stderr io.Writer
+	exitFn func(int)
+	width  int
+}
+
+// setOutput sets the writers of the console.
+func (c *console) setOutput(stdout io.Writer, stderr io.Writer) {
+	c.stdout = stdout
+	c.stderr = stderr
+}
+
+// outputWriter returns the writer used for usage and
+// version information.
+func (c console) outputWriter() io.Writer {
+	if c.stdout == nil {
+		return os.Stdout
+	}
+
+	return c.stdout
+}
+
+// errorWriter returns the writer used for error
+// and warning messages.
+func (c console) errorWriter() io.Writer {
+	if c.stderr == nil {
+		return os.Stderr
+	}
+
+	return c.stderr
+}
+
+// warn prints the passed message as a warning to the
+// error writer of the console.
+func (c console) warn(msg string) {
+	fmt.Fprintf(c.errorWriter(), "Warning: %v\n", msg)
+}
+
+// exit calls the exit function of the console with
+// the passed code.
+func (c console) exit(code int) {
+	if c.exitFn == nil {
+		os.Exit(code)
+	}
+
+	c.exitFn(code)
+}
+
+// inherit fills the unset parts of the console with
+// those of the passed console.
+func (c *console) inherit(p console) {
+	if c.stdout == nil {
+		c.stdout = p.stdout
+	}
+
+	if c.stderr == nil {
+		c.stderr = p.stderr
+	}
+
+	if c.exitFn == nil {
+		c.exitFn = p.exitFn
+	}
+
+	if c.width == 0 {
+		c.width = p.width
+	}
 }
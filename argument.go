@@ -2,10 +2,12 @@ package argue
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"reflect"
 	"sort"
 	"strings"
+	"text/template"
 
 	"github.com/rburmorrison/go-argue/internal/mirror"
 )
@@ -21,8 +23,23 @@ type Argument struct {
 	ShowDesc        bool
 	ShowVersion     bool
 
+	// CollectErrors, if true, makes DisputeCustom
+	// check every argument before failing, returning
+	// all of the errors that it found joined together.
+	CollectErrors bool
+
+	// EnvPrefix, if set, gives every flag fact without
+	// an explicit environment variable one named after
+	// the prefix and the fact, as in PREFIX_NAME.
+	EnvPrefix string
+
 	commandSuffix string
 	baseStruct    interface{}
+	configFile    string
+	configSection string
+	usageTemplate *template.Template
+	groupOrder    []string
+	console
 }
 
 func newArgumentFromStruct(agmt Argument, str interface{}) Argument {
@@ -47,6 +64,11 @@ func newArgumentFromStruct(agmt Argument, str interface{}) Argument {
 		var init byte
 		positional := false
 		required := false
+		counter := false
+		negatable := false
+		hidden := false
+		env := tag.Get("env")
+		group := tag.Get("group")
 		name := breakCammelCase(field.Name)
 		name = StandardizeFactName(name)
 
@@ -83,15 +105,29 @@ func newArgumentFromStruct(agmt Argument, str interface{}) Argument {
 					required = true
 				} else if o == "POSITIONAL" {
 					positional = true
+				} else if o == "COUNT" {
+					counter = true
+				} else if o == "NEGATABLE" {
+					negatable = true
+				} else if o == "HIDDEN" {
+					hidden = true
 				}
 			}
 		}
 
+		var fact *Fact
 		fieldPointer := indir.Field(i).Addr().Interface()
 		if positional {
-			agmt.AddPositionalFact(name, tag.Get("help"), fieldPointer).SetRequired(required)
+			fact = agmt.AddPositionalFact(name, tag.Get("help"), fieldPointer).SetRequired(required)
 		} else {
-			agmt.AddFlagFact(name, tag.Get("help"), fieldPointer).SetRequired(required).SetInitial(init)
+			fact = agmt.AddFlagFact(name, tag.Get("help"), fieldPointer).SetRequired(required).SetInitial(init).SetCounter(counter).SetNegatable(negatable).SetEnv(env).SetGroup(group).SetHidden(hidden)
+		}
+
+		fact.SetDeprecated(tag.Get("deprecated"))
+
+		// Apply the default value if one is specified
+		if val, ok := tag.Lookup("default"); ok {
+			fact.SetDefault(val)
 		}
 	}
 
@@ -144,6 +180,32 @@ func NewEmptyArgument() Argument {
 	return agmt
 }
 
+// SetOutput sets the writers that the received
+// argument prints usage and version information to,
+// and error messages to, respectively. A nil writer
+// restores the default, which is the standard output
+// for information and the standard error for errors.
+func (a *Argument) SetOutput(stdout io.Writer, stderr io.Writer) {
+	a.setOutput(stdout, stderr)
+}
+
+// SetExitFunc sets the function that the received
+// argument calls to exit the program in strict mode.
+// By default, os.Exit is used.
+func (a *Argument) SetExitFunc(f func(int)) {
+	a.exitFn = f
+}
+
+// SetWidth sets the number of columns that the usage
+// information of the received argument is wrapped
+// to. By default, the width is taken from the
+// COLUMNS environment variable or the terminal, and
+// nothing is wrapped if neither is available. A
+// negative width disables wrapping.
+func (a *Argument) SetWidth(width int) {
+	a.width = width
+}
+
 // Dispute passes os.Args[1:] to DisputeCustom as
 // these are the most common arguments to parse.
 func (a Argument) Dispute(strict bool) error {
@@ -156,192 +218,434 @@ func (a Argument) Dispute(strict bool) error {
 // arguments as it expects to. Optionally, setting
 // "strict" to true will automatically print an error
 // message to the console and exit the program on
-// failing.
+// failing. Errors that occur while parsing are of
+// type *ParseError. If help or version information is
+// requested, it is printed and the program exits in
+// strict mode, otherwise ErrHelpRequested or
+// ErrVersionRequested is returned.
 func (a Argument) DisputeCustom(arguments []string, strict bool) error {
-	ps, fm := a.SplitArguments(arguments)
+	ps, pi, flags := a.splitArguments(arguments)
 
 	// Handle printing help and version if they exist
-	for k := range fm {
+	for _, t := range flags {
+		k := t.key
 		if k == "-h" || k == "--help" {
 			a.PrintUsage()
-			os.Exit(0)
+			if strict {
+				a.exit(0)
+			}
+
+			return ErrHelpRequested
 		}
 
 		if a.ShowVersion && (k == "-v" || k == "--version") {
 			a.PrintVersion()
-			os.Exit(0)
+			if strict {
+				a.exit(0)
+			}
+
+			return ErrVersionRequested
 		}
 	}
 
+	// Keep track of every error found. Unless all
+	// errors are being collected, parsing stops at the
+	// first one
+	var errs []*ParseError
+	report := func(perr *ParseError) bool {
+		errs = append(errs, perr)
+		return !a.CollectErrors
+	}
+
 	// Check for unknown flags
-	for k := range fm {
-		_, nok := a.DressedNameExists(k)
-		_, iok := a.DressedInitialExists(k)
-		if !nok && !iok {
-			if strict {
-				a.PrintError("unknown flag " + k + " provided")
+	for _, t := range flags {
+		if _, ok := a.dressedFact(t.key); !ok {
+			suggestions := a.flagSuggestions(t.key)
+			perr := newParseError(ErrUnknownFlag, "unknown flag "+t.key+" provided"+suggestionMessage(suggestions))
+			perr.Suggestions = suggestions
+			if report(perr.at(arguments, t.index)) {
+				return a.fail(strict, errs)
 			}
-
-			return ErrUnknownFlag
 		}
 	}
 
-	// Check for extra positional arguments
-	if len(ps) > len(a.PositionalFacts) {
-		if strict {
-			a.PrintError("too many positional arguments provided")
+	// Check for extra positional arguments, which are
+	// only allowed if the last positional is variadic
+	_, variadic := a.VariadicFact()
+	if !variadic && len(ps) > len(a.PositionalFacts) {
+		perr := newParseError(ErrExtraPositionals, "too many positional arguments provided")
+		if report(perr.at(arguments, pi[len(a.PositionalFacts)])) {
+			return a.fail(strict, errs)
 		}
 
-		return ErrExtraPositionals
+		ps = ps[:len(a.PositionalFacts)]
 	}
 
-	// Check if all required flags are present
-	for _, f := range a.RequiredFlags() {
-		var flagFound bool
-		for k := range fm {
-			if k == f.DressedInitial() || k == f.DressedName() {
-				flagFound = true
+	// Determine which flag facts were provided, warning
+	// once about each deprecated one
+	provided := make(map[*Fact]bool)
+	for _, t := range flags {
+		if f, ok := a.dressedFact(t.key); ok {
+			if f.Deprecated != "" && !provided[f] {
+				a.warn(fmt.Sprintf("flag %s is deprecated, %s", f.DressedName(), f.Deprecated))
 			}
+
+			provided[f] = true
 		}
+	}
 
-		if !flagFound {
-			if strict {
-				a.PrintError(fmt.Sprintf("flag %s is required", f.DressedName()))
+	// Load values from the configuration file for flags
+	// that were not provided
+	found := make(map[*Fact]bool)
+	if perr := a.loadConfig(flags, provided, found); perr != nil {
+		if report(perr) {
+			return a.fail(strict, errs)
+		}
+	}
+
+	// Fall back on environment variables for flags that
+	// were not provided
+	for _, f := range a.FlagFacts {
+		env := a.envName(f)
+		if provided[f] || env == "" {
+			continue
+		}
+
+		v, ok := os.LookupEnv(env)
+		if !ok {
+			continue
+		}
+
+		err := f.setText(v)
+		if err != nil {
+			perr := newParseError(ErrWrongType, "environment variable "+env+" "+err.Error())
+			perr.Fact = f
+			perr.Err = err
+			if report(perr) {
+				return a.fail(strict, errs)
 			}
 
-			return ErrMissingFlag
+			continue
 		}
+
+		found[f] = true
 	}
 
-	// Make sure the last required argument is satisfied
-	lastPos := -1
-	for i, f := range a.PositionalFacts {
-		if f.Required {
-			lastPos = i
+	// Check if all required flags are present
+	for _, f := range a.RequiredFlags() {
+		if !provided[f] && !found[f] {
+			perr := newParseError(ErrMissingFlag, fmt.Sprintf("flag %s is required", f.DressedName()))
+			perr.Fact = f
+			if report(perr) {
+				return a.fail(strict, errs)
+			}
 		}
 	}
 
-	if lastPos > -1 && len(ps) < lastPos+1 {
-		if strict {
-			if lastPos+1 == 1 {
-				a.PrintError(fmt.Sprintf("expected %d positional argument, but got %d", lastPos+1, len(ps)))
-			} else {
-				a.PrintError(fmt.Sprintf("expected %d positional arguments, but got %d", lastPos+1, len(ps)))
-			}
+	// Make sure the last required argument is satisfied
+	minimum := a.minimumPositionals()
+	if len(ps) < minimum {
+		expected := fmt.Sprintf("%d", minimum)
+		if variadic {
+			expected = "at least " + expected
 		}
 
-		return ErrMissingPositionals
+		var msg string
+		if minimum == 1 {
+			msg = fmt.Sprintf("expected %s positional argument, but got %d", expected, len(ps))
+		} else {
+			msg = fmt.Sprintf("expected %s positional arguments, but got %d", expected, len(ps))
+		}
+
+		perr := newParseError(ErrMissingPositionals, msg)
+		if len(ps) < len(a.PositionalFacts) {
+			perr.Fact = a.PositionalFacts[len(ps)]
+		}
+
+		if report(perr) {
+			return a.fail(strict, errs)
+		}
 	}
 
-	// Set values for positional facts
+	// Set values for positional facts, giving any
+	// remaining values to a variadic fact
 	for i, s := range ps {
-		fact := a.PositionalFacts[i]
+		fact := a.PositionalFacts[len(a.PositionalFacts)-1]
+		if i < len(a.PositionalFacts) {
+			fact = a.PositionalFacts[i]
+		}
+
+		if fact.Type.slice() && i == len(a.PositionalFacts)-1 {
+			fact.clear()
+		}
+
 		err := fact.SetValue(s)
 		if err != nil {
-			if strict {
-				a.PrintError("positional argument " + UpperFactName(fact.Name) + " " + err.Error())
+			perr := newParseError(ErrWrongType, "positional argument "+UpperFactName(fact.Name)+" "+err.Error())
+			perr.Fact = fact
+			perr.Err = err
+			if report(perr.at(arguments, pi[i])) {
+				return a.fail(strict, errs)
 			}
-
-			return ErrWrongType
 		}
 	}
 
-	// Set values for flag facts
-	for k, v := range fm {
+	// Set values for flag facts in the order they were
+	// provided
+	cleared := make(map[*Fact]bool)
+	for _, t := range flags {
+		// Get the fact that correspons with the key,
+		// skipping unknown flags that were already reported
+		f, ok := a.dressedFact(t.key)
+		if !ok {
+			continue
+		}
+
 		// Check if the value provided was nil
-		if v == nil {
-			if strict {
-				a.PrintError("no value was provided for " + k)
+		if t.value == nil {
+			perr := newParseError(ErrNilValue, "no value was provided for "+t.key)
+			perr.Fact = f
+			if report(perr.at(arguments, t.index)) {
+				return a.fail(strict, errs)
 			}
 
-			return ErrNilValue
+			continue
 		}
 
-		// Get the fact that correspons with the key
-		f, nameExists := a.DressedNameExists(k)
-		if !nameExists {
-			f2, _ := a.DressedInitialExists(k)
-			f = f2
+		// Repeatable facts replace their previous contents
+		// the first time they appear
+		if f.Type.accumulates() && !cleared[f] {
+			f.clear()
+			cleared[f] = true
 		}
 
-		err := f.SetValue(v)
+		err := f.SetValue(t.value)
 		if err != nil {
-			if strict {
-				a.PrintError(k + " " + err.Error())
+			perr := newParseError(ErrWrongType, t.key+" "+err.Error())
+			perr.Fact = f
+			perr.Err = err
+			if report(perr.at(arguments, t.valueIndex)) {
+				return a.fail(strict, errs)
 			}
-
-			return ErrWrongType
 		}
 	}
 
+	if len(errs) > 0 {
+		return a.fail(strict, errs)
+	}
+
 	return nil
 }
 
+// fail prints the passed errors and exits the
+// program if strict is true. The errors are returned
+// as one so that they may be passed on to the
+// caller.
+func (a Argument) fail(strict bool, errs []*ParseError) error {
+	if strict {
+		a.printErrors(errorMessages(errs))
+	}
+
+	return joinParseErrors(errs)
+}
+
 // SplitArguments splits command-line arguments into
 // their "positional" and "flag" categories. They are
-// returned in that order. The passed arguments
-// should not include the call to the binary.
+// returned in that order. Values may be attached to
+// flags with an equals sign, as in "--name=value" or
+// "-n=value". Initials may be combined into a single
+// cluster, as in "-abc", where the first initial
+// that requires a value takes the remainder of the
+// cluster, or the next argument, as its value. Any
+// arguments after a "--" terminator are treated as
+// positional. If a flag is repeated, only its last
+// value is kept. The passed arguments should not
+// include the call to the binary.
 func (a Argument) SplitArguments(arguments []string) ([]string, map[string]interface{}) {
-	// Define structures to return
-	var positionalSlice []string
+	positionalSlice, _, flags := a.splitArguments(arguments)
+
 	var flagMap = make(map[string]interface{})
-	for len(arguments) > 0 {
-		arg := arguments[0]
-		if !flagReg.MatchString(arg) {
-			positionalSlice = append(positionalSlice, arg)
+	for _, t := range flags {
+		flagMap[t.key] = t.value
+	}
 
-			// Remove this argument from the total list
-			arguments = arguments[1:]
-		} else {
-			// If the argument is not a defined fact, treat it as
-			// a boolean flag, otherwise get the fact that this
-			// argument represents
-			f, nameExists := a.DressedNameExists(arg)
-			if !nameExists {
-				f2, initialExists := a.DressedInitialExists(arg)
-				if !initialExists {
-					flagMap[arg] = true
-
-					// Remove this argument from the total list and
-					// restart the loop
-					arguments = arguments[1:]
-					continue
-				} else {
-					f = f2
-				}
+	return positionalSlice, flagMap
+}
+
+// splitArguments performs the work of SplitArguments,
+// but keeps every flag in the order it was provided.
+// The index of each positional value within the
+// passed arguments is returned alongside them.
+func (a Argument) splitArguments(arguments []string) ([]string, []int, []flagToken) {
+	// Define structures to return
+	var positionalSlice []string
+	var positionalIndexes []int
+	var flagTokens []flagToken
+	for i := 0; i < len(arguments); {
+		// Stop parsing flags once the terminator is found
+		if arguments[i] == "--" {
+			for j := i + 1; j < len(arguments); j++ {
+				positionalSlice = append(positionalSlice, arguments[j])
+				positionalIndexes = append(positionalIndexes, j)
 			}
+			break
+		}
 
-			// Treat boolean arguments specially since they do
-			// not require a value
-			if f.Type == FactTypeBool {
-				flagMap[arg] = true
+		if !a.isFlag(arguments[i]) {
+			positionalSlice = append(positionalSlice, arguments[i])
+			positionalIndexes = append(positionalIndexes, i)
 
-				// Remove this argument from the total list
-				arguments = arguments[1:]
-			} else {
-				// If the next argument is another flag, or if this
-				// is the last argument, assign it a nil value and
-				// continue on
-				if len(arguments) <= 1 || flagReg.MatchString(arguments[1]) {
-					flagMap[arg] = nil
-
-					// Remove this argument from the total list and
-					// restart the loop
-					arguments = arguments[1:]
-					continue
-				}
+			// Move on to the next argument
+			i++
+			continue
+		}
+
+		// Record where each flag and its value were found.
+		// Only the last flag of a cluster may take its value
+		// from the next argument
+		tokens, n := a.splitFlag(arguments[i:])
+		for j := range tokens {
+			tokens[j].index = i
+			tokens[j].valueIndex = i
+		}
 
-				// Treat the next argument as the value to this one
-				flagMap[arg] = arguments[1]
+		if n > 1 {
+			tokens[len(tokens)-1].valueIndex = i + 1
+		}
+
+		flagTokens = append(flagTokens, tokens...)
+
+		// Skip the arguments that the flag consumed
+		i += n
+	}
+
+	return positionalSlice, positionalIndexes, flagTokens
+}
 
-				// Remove this argument and the next from the total
-				// list
-				arguments = arguments[2:]
+// flagToken represents a single flag and its value
+// found while splitting arguments.
+type flagToken struct {
+	key        string
+	value      interface{}
+	index      int
+	valueIndex int
+}
+
+// splitFlag breaks the flag at the start of the
+// passed arguments into its tokens. The number of
+// arguments that were consumed is also returned.
+func (a Argument) splitFlag(arguments []string) ([]flagToken, int) {
+	arg := arguments[0]
+
+	// Handle long names, which only ever represent a
+	// single flag
+	if strings.HasPrefix(arg, "--") {
+		// Values attached with an equals sign always belong
+		// to the flag itself
+		name, value, hasValue := splitFlagValue(arg)
+		if hasValue {
+			return []flagToken{{key: name, value: value}}, 1
+		}
+
+		// Negated names turn their fact off
+		f, ok := a.DressedNameExists(name)
+		if !ok && strings.HasPrefix(name, "--no-") {
+			f2, ok2 := a.DressedNameExists("--" + strings.TrimPrefix(name, "--no-"))
+			if ok2 && f2.Negatable {
+				return []flagToken{{key: f2.DressedName(), value: false}}, 1
 			}
 		}
+
+		// If the argument is not a defined fact, treat it as
+		// a boolean flag. Boolean and counter facts do not
+		// take values either
+		if !ok || !f.Type.takesValue() {
+			return []flagToken{{key: name, value: true}}, 1
+		}
+
+		value2, n := a.flagValue(f, arguments[1:])
+		return []flagToken{{key: name, value: value2}}, n + 1
 	}
 
-	return positionalSlice, flagMap
+	// Handle a cluster of initials
+	var tokens []flagToken
+	for i := 1; i < len(arg); i++ {
+		key := "-" + string(arg[i])
+		rest := arg[i+1:]
+
+		// An equals sign ends the cluster and provides the
+		// value for the initial before it
+		if strings.HasPrefix(rest, "=") {
+			return append(tokens, flagToken{key: key, value: rest[1:]}), 1
+		}
+
+		// Unknown, boolean, and counter initials do not take
+		// values
+		f, ok := a.DressedInitialExists(key)
+		if !ok || !f.Type.takesValue() {
+			tokens = append(tokens, flagToken{key: key, value: true})
+			continue
+		}
+
+		// The first initial that requires a value takes the
+		// remainder of the cluster, or the next argument
+		if rest != "" {
+			return append(tokens, flagToken{key: key, value: rest}), 1
+		}
+
+		value, n := a.flagValue(f, arguments[1:])
+		return append(tokens, flagToken{key: key, value: value}), n + 1
+	}
+
+	return tokens, 1
+}
+
+// flagValue returns the value for the passed fact at
+// the start of the passed arguments along with the
+// number of arguments it used. If the next argument
+// is another flag, or if there are no more
+// arguments, nil is returned instead. The "--"
+// terminator is never used as a value, but negative
+// numbers are accepted by numeric facts.
+func (a Argument) flagValue(f *Fact, arguments []string) (interface{}, int) {
+	if len(arguments) == 0 || arguments[0] == "--" {
+		return nil, 0
+	}
+
+	if f.Type.signed() && numberReg.MatchString(arguments[0]) {
+		return arguments[0], 1
+	}
+
+	if a.isFlag(arguments[0]) {
+		return nil, 0
+	}
+
+	return arguments[0], 1
+}
+
+// isFlag returns true if the passed argument should
+// be treated as a flag rather than a value. Negative
+// numbers are only treated as flags if a flag fact
+// has a digit as its initial.
+func (a Argument) isFlag(arg string) bool {
+	if numberReg.MatchString(arg) && !a.digitInitialExists() {
+		return false
+	}
+
+	return flagReg.MatchString(arg)
+}
+
+// digitInitialExists returns true if any flag facts
+// within the argument have a digit as their initial,
+// false otherwise.
+func (a Argument) digitInitialExists() bool {
+	for _, f := range a.FlagFacts {
+		if f.Initial >= '0' && f.Initial <= '9' {
+			return true
+		}
+	}
+
+	return false
 }
 
 // RequiredPositionals returns all positional facts
@@ -380,6 +684,7 @@ func (a *Argument) AddFlagFact(name string, help string, v interface{}) *Fact {
 	}
 
 	fact := NewFact(help, name, a.GenerateInitial(name), false, false, v)
+	fact.order = len(a.FlagFacts)
 	a.FlagFacts = append(a.FlagFacts, &fact)
 	a.SortFlagFacts()
 	return &fact
@@ -394,11 +699,51 @@ func (a *Argument) AddPositionalFact(name string, help string, v interface{}) *F
 		panic("argument: name already exits within this argument")
 	}
 
+	if _, ok := a.VariadicFact(); ok {
+		panic("argue: a positional fact can not follow a variadic positional fact")
+	}
+
 	fact := NewFact(help, name, 0, true, true, v)
 	a.PositionalFacts = append(a.PositionalFacts, &fact)
 	return &fact
 }
 
+// VariadicFact returns the last positional fact of
+// the received argument if it is a slice, which
+// collects all remaining positional values.
+func (a Argument) VariadicFact() (*Fact, bool) {
+	if len(a.PositionalFacts) == 0 {
+		return nil, false
+	}
+
+	f := a.PositionalFacts[len(a.PositionalFacts)-1]
+	if !f.Type.slice() {
+		return nil, false
+	}
+
+	return f, true
+}
+
+// minimumPositionals returns the number of
+// positional values that must be provided to satisfy
+// every required positional fact.
+func (a Argument) minimumPositionals() int {
+	minimum := 0
+	for i, f := range a.PositionalFacts {
+		if f.Required {
+			minimum = i + 1
+		}
+	}
+
+	// A required variadic fact needs its minimum number
+	// of values
+	if f, ok := a.VariadicFact(); ok && f.Required && f.Minimum > 1 {
+		minimum += f.Minimum - 1
+	}
+
+	return minimum
+}
+
 // SortFlagFacts sorts the flag facts in an argument
 // by fact type.
 func (a *Argument) SortFlagFacts() {
@@ -407,6 +752,71 @@ func (a *Argument) SortFlagFacts() {
 	})
 }
 
+// SetGroupOrder sets the order that the passed
+// groups of flag facts are shown in usage
+// information. Groups that are not passed follow in
+// the order that their first fact was added.
+func (a *Argument) SetGroupOrder(groups ...string) {
+	a.groupOrder = groups
+}
+
+// flagGroups returns the visible flag facts of the
+// received argument that do not belong to a group,
+// followed by the groups of the rest in the order
+// that they are shown.
+func (a Argument) flagGroups() ([]*Fact, []factGroup) {
+	var ungrouped []*Fact
+	var groups []factGroup
+	index := make(map[string]int)
+	for _, f := range a.FlagFacts {
+		if f.Hidden {
+			continue
+		}
+
+		if f.Group == "" {
+			ungrouped = append(ungrouped, f)
+			continue
+		}
+
+		i, ok := index[f.Group]
+		if !ok {
+			i = len(groups)
+			index[f.Group] = i
+			groups = append(groups, factGroup{title: f.Group, order: f.order})
+		}
+
+		groups[i].facts = append(groups[i].facts, f)
+		if f.order < groups[i].order {
+			groups[i].order = f.order
+		}
+	}
+
+	// Configured groups come first, followed by the
+	// rest in declaration order
+	rank := func(g factGroup) int {
+		for i, title := range a.groupOrder {
+			if title == g.title {
+				return i - len(a.groupOrder)
+			}
+		}
+
+		return g.order
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		return rank(groups[i]) < rank(groups[j])
+	})
+
+	return ungrouped, groups
+}
+
+// factGroup holds the flag facts that belong to a
+// group.
+type factGroup struct {
+	title string
+	order int
+	facts []*Fact
+}
+
 // DressedNameExists returns true if any flag facts
 // within the argument has the dressed name passed,
 // false otherwise.
@@ -437,6 +847,105 @@ func (a Argument) DressedInitialExists(di string) (*Fact, bool) {
 	return nil, false
 }
 
+// dressedFact returns the flag fact that matches the
+// passed dressed name or dressed initial.
+func (a Argument) dressedFact(d string) (*Fact, bool) {
+	if f, ok := a.DressedNameExists(d); ok {
+		return f, true
+	}
+
+	return a.DressedInitialExists(d)
+}
+
+// flagSuggestions returns the dressed names or
+// initials of the flag facts that the passed unknown
+// flag may have been meant to be.
+func (a Argument) flagSuggestions(key string) []string {
+	names := []string{"help"}
+	initials := []byte{'h'}
+	if a.ShowVersion {
+		names = append(names, "version")
+		initials = append(initials, 'v')
+	}
+
+	for _, f := range a.FlagFacts {
+		if f.Hidden {
+			continue
+		}
+
+		names = append(names, f.Name)
+		initials = append(initials, f.Initial)
+		if f.Negatable {
+			names = append(names, "no-"+f.Name)
+		}
+	}
+
+	if !strings.HasPrefix(key, "--") {
+		return initialSuggestions(key, names, initials)
+	}
+
+	suggestions := suggest(strings.TrimPrefix(key, "--"), names)
+	for i := range suggestions {
+		suggestions[i] = "--" + suggestions[i]
+	}
+
+	return suggestions
+}
+
+// initialSuggestions returns the dressed initials
+// that differ from the passed unknown initial only in
+// case. If there are none, the dressed names that
+// start with the same letter are returned instead.
+func initialSuggestions(key string, names []string, initials []byte) []string {
+	if len(key) != 2 {
+		return nil
+	}
+
+	var suggestions []string
+	for _, i := range initials {
+		if i != 0 && i != key[1] && strings.EqualFold(string(i), key[1:]) {
+			suggestions = append(suggestions, "-"+string(i))
+		}
+	}
+
+	if len(suggestions) > 0 {
+		return suggestions
+	}
+
+	for _, n := range names {
+		if strings.HasPrefix(strings.ToLower(n), strings.ToLower(key[1:])) {
+			suggestions = append(suggestions, "--"+n)
+		}
+	}
+
+	sort.Strings(suggestions)
+	return suggestions
+}
+
+// envName returns the name of the environment
+// variable that the passed flag fact falls back on,
+// or an empty string if it has none.
+func (a Argument) envName(f *Fact) string {
+	if f.Env != "" {
+		return f.Env
+	}
+
+	if a.EnvPrefix == "" {
+		return ""
+	}
+
+	// Only facts that belong to the argument receive a
+	// generated name
+	for _, f2 := range a.FlagFacts {
+		if f2 == f {
+			prefix := strings.TrimSuffix(strings.ToUpper(a.EnvPrefix), "_")
+			return prefix + "_" + UpperFactName(f.Name)
+		}
+	}
+
+	return ""
+}
+
 // NameExists returns true if any facts within the
 // argument has the name passed, false otherwise.
 func (a Argument) NameExists(n string) (*Fact, bool) {
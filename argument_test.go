@@ -1,6 +1,10 @@
 package argue
 
 import (
+	"bytes"
+	"errors"
+	"os"
+	"strings"
 	"testing"
 )
 
@@ -35,6 +39,44 @@ func TestSplitArguments(t *testing.T) {
 	}
 }
 
+func TestSplitArgumentsEquals(t *testing.T) {
+	var tInt int
+	var tString string
+	var tTag string
+
+	agmt := NewArgument("This is a test of the argument library.", "2.0.0")
+	agmt.AddFlagFact("int", "this is an integer", &tInt)
+	agmt.AddFlagFact("string", "this is a string", &tString)
+	agmt.AddFlagFact("tag", "this is a tag", &tTag)
+
+	arguments := []string{"--string=a=b", "-i=42", "--tag=", "asdf"}
+	ps, fm := agmt.SplitArguments(arguments)
+	if len(ps) != 1 {
+		t.Errorf("SplitArguments was incorrect, got: %d positionals, expected %d positionals", len(ps), 1)
+	}
+
+	if fm["--string"] != "a=b" {
+		t.Errorf("SplitArguments was incorrect, got: fm[\"--string\"] == %v, expected: %v", fm["--string"], "a=b")
+	}
+
+	if fm["-i"] != "42" {
+		t.Errorf("SplitArguments was incorrect, got: fm[\"-i\"] == %v, expected: %v", fm["-i"], "42")
+	}
+
+	if v, ok := fm["--tag"]; !ok || v != "" {
+		t.Errorf("SplitArguments was incorrect, got: fm[\"--tag\"] == %v, expected an empty string", v)
+	}
+
+	err := agmt.DisputeCustom([]string{"--string=out.txt", "--int=7"}, false)
+	if err != nil {
+		t.Errorf("DisputeCustom was incorrect, expected: nil, got %v", err)
+	}
+
+	if tString != "out.txt" || tInt != 7 {
+		t.Errorf("DisputeCustom was incorrect, got: %s and %d, expected: out.txt and 7", tString, tInt)
+	}
+}
+
 func TestDisputeCustom(t *testing.T) {
 	var tUInt uint
 	var tInt int
@@ -53,13 +95,582 @@ func TestDisputeCustom(t *testing.T) {
 
 	arguments := []string{"--int", "123", "--string", "test string", "asdf"}
 	err := agmt.DisputeCustom(arguments, false)
-	if err != ErrMissingPositionals {
+	if !errors.Is(err, ErrMissingPositionals) {
 		t.Errorf("DisputeCustom was incorrect, expected: ErrMissingPositionals, got %v", err)
 	}
 
 	arguments = []string{"--int", "123", "--string", "test string", "asdf", "123", "--other"}
 	err = agmt.DisputeCustom(arguments, false)
-	if err != ErrUnknownFlag {
+	if !errors.Is(err, ErrUnknownFlag) {
+		t.Errorf("DisputeCustom was incorrect, expected: ErrUnknownFlag, got %v", err)
+	}
+}
+
+func TestSplitArgumentsClusters(t *testing.T) {
+	var tVerbose bool
+	var tQuiet bool
+	var tNum int
+	var tFile string
+
+	agmt := NewEmptyArgument()
+	agmt.AddFlagFact("verbose", "this is a boolean", &tVerbose)
+	agmt.AddFlagFact("quiet", "this is another boolean", &tQuiet)
+	agmt.AddFlagFact("num", "this is an integer", &tNum)
+	agmt.AddFlagFact("file", "this is a string", &tFile)
+
+	ps, fm := agmt.SplitArguments([]string{"-vqf", "out.txt", "-n5", "asdf"})
+	if len(ps) != 1 {
+		t.Errorf("SplitArguments was incorrect, got: %d positionals, expected %d positionals", len(ps), 1)
+	}
+
+	if fm["-v"] != true || fm["-q"] != true {
+		t.Errorf("SplitArguments was incorrect, expected -v and -q to be set")
+	}
+
+	if fm["-f"] != "out.txt" {
+		t.Errorf("SplitArguments was incorrect, got: fm[\"-f\"] == %v, expected: %v", fm["-f"], "out.txt")
+	}
+
+	if fm["-n"] != "5" {
+		t.Errorf("SplitArguments was incorrect, got: fm[\"-n\"] == %v, expected: %v", fm["-n"], "5")
+	}
+
+	err := agmt.DisputeCustom([]string{"-vx"}, false)
+	if !errors.Is(err, ErrUnknownFlag) {
+		t.Errorf("DisputeCustom was incorrect, expected: ErrUnknownFlag, got %v", err)
+	}
+
+	err = agmt.DisputeCustom([]string{"-qfin.txt"}, false)
+	if err != nil || !tQuiet || tFile != "in.txt" {
+		t.Errorf("DisputeCustom was incorrect, got: %v, %t, %s, expected: nil, true, in.txt", err, tQuiet, tFile)
+	}
+}
+
+func TestSplitArgumentsTerminator(t *testing.T) {
+	var tString string
+	var tPos string
+	var tOther string
+
+	agmt := NewEmptyArgument()
+	agmt.AddFlagFact("string", "this is a string", &tString)
+	agmt.AddPositionalFact("pos", "this is a positional string", &tPos)
+	agmt.AddPositionalFact("other", "this is another positional string", &tOther)
+
+	arguments := []string{"--string", "asdf", "--", "-weird.txt", "--string"}
+	err := agmt.DisputeCustom(arguments, false)
+	if err != nil {
+		t.Errorf("DisputeCustom was incorrect, expected: nil, got %v", err)
+	}
+
+	if tString != "asdf" || tPos != "-weird.txt" || tOther != "--string" {
+		t.Errorf("DisputeCustom was incorrect, got: %s, %s, %s, expected: asdf, -weird.txt, --string", tString, tPos, tOther)
+	}
+
+	before, after, ok := SplitTerminator(arguments)
+	if !ok || len(before) != 2 || len(after) != 2 {
+		t.Errorf("SplitTerminator was incorrect, got: %v, %v, %t", before, after, ok)
+	}
+}
+
+func TestDisputeCustomNegativeNumbers(t *testing.T) {
+	var tOffset int
+	var tScale float64
+	var tName string
+	var tX int
+
+	agmt := NewEmptyArgument()
+	agmt.AddFlagFact("offset", "this is an integer", &tOffset)
+	agmt.AddFlagFact("scale", "this is a float", &tScale)
+	agmt.AddFlagFact("name", "this is a string", &tName)
+	agmt.AddPositionalFact("x", "this is a positional integer", &tX)
+
+	err := agmt.DisputeCustom([]string{"--offset", "-5", "-s", "-0.5", "-3"}, false)
+	if err != nil {
+		t.Errorf("DisputeCustom was incorrect, expected: nil, got %v", err)
+	}
+
+	if tOffset != -5 || tScale != -0.5 || tX != -3 {
+		t.Errorf("DisputeCustom was incorrect, got: %d, %f, %d, expected: -5, -0.5, -3", tOffset, tScale, tX)
+	}
+
+	var tDigit bool
+	agmt.AddFlagFact("digit", "this is a boolean", &tDigit).SetInitial('5')
+	err = agmt.DisputeCustom([]string{"--name", "-5", "1"}, false)
+	if !errors.Is(err, ErrNilValue) {
+		t.Errorf("DisputeCustom was incorrect, expected: ErrNilValue, got %v", err)
+	}
+
+	err = agmt.DisputeCustom([]string{"--offset", "-5", "1"}, false)
+	if err != nil || tOffset != -5 {
+		t.Errorf("DisputeCustom was incorrect, got: %v, %d, expected: nil, -5", err, tOffset)
+	}
+
+	// Unsigned facts reject negative numbers
+	var tCount uint
+	counted := NewEmptyArgument()
+	counted.AddFlagFact("count", "this is an unsigned integer", &tCount)
+	for _, args := range [][]string{{"--count", "-5"}, {"--count=-5"}} {
+		err = counted.DisputeCustom(args, false)
+		if !errors.Is(err, ErrWrongType) || tCount != 0 {
+			t.Errorf("DisputeCustom was incorrect for %v, got: %v, %d, expected: ErrWrongType, 0", args, err, tCount)
+		}
+	}
+}
+
+func TestDisputeCustomRepeatedFlags(t *testing.T) {
+	type example struct {
+		Include []string
+		Level   []int
+	}
+
+	e := example{Include: []string{"default"}}
+	agmt := NewEmptyArgumentFromStruct(&e)
+
+	arguments := []string{"--include", "a", "-i", "b", "--include=c", "-l", "-1", "-l2"}
+	err := agmt.DisputeCustom(arguments, false)
+	if err != nil {
+		t.Errorf("DisputeCustom was incorrect, expected: nil, got %v", err)
+	}
+
+	if len(e.Include) != 3 || e.Include[0] != "a" || e.Include[2] != "c" {
+		t.Errorf("DisputeCustom was incorrect, got: %v, expected: [a b c]", e.Include)
+	}
+
+	if len(e.Level) != 2 || e.Level[0] != -1 || e.Level[1] != 2 {
+		t.Errorf("DisputeCustom was incorrect, got: %v, expected: [-1 2]", e.Level)
+	}
+
+	f, _ := agmt.NameExists("include")
+	if f.usageHeader() != "-i, --include VALUE..." {
+		t.Errorf("usageHeader was incorrect, got: %s, expected: %s", f.usageHeader(), "-i, --include VALUE...")
+	}
+}
+
+func TestDisputeCustomVariadic(t *testing.T) {
+	type example struct {
+		Command string   `options:"required,positional"`
+		Files   []string `options:"required,positional"`
+	}
+
+	var e example
+	agmt := NewEmptyArgumentFromStruct(&e)
+
+	err := agmt.DisputeCustom([]string{"compile", "a.go", "b.go", "c.go"}, false)
+	if err != nil {
+		t.Errorf("DisputeCustom was incorrect, expected: nil, got %v", err)
+	}
+
+	if e.Command != "compile" || len(e.Files) != 3 {
+		t.Errorf("DisputeCustom was incorrect, got: %s, %v, expected: compile, [a.go b.go c.go]", e.Command, e.Files)
+	}
+
+	err = agmt.DisputeCustom([]string{"compile"}, false)
+	if !errors.Is(err, ErrMissingPositionals) {
+		t.Errorf("DisputeCustom was incorrect, expected: ErrMissingPositionals, got %v", err)
+	}
+
+	f, _ := agmt.VariadicFact()
+	f.SetMinimum(2)
+	err = agmt.DisputeCustom([]string{"compile", "a.go"}, false)
+	if !errors.Is(err, ErrMissingPositionals) {
+		t.Errorf("DisputeCustom was incorrect, expected: ErrMissingPositionals, got %v", err)
+	}
+
+	if f.usageHeader() != "FILES..." {
+		t.Errorf("usageHeader was incorrect, got: %s, expected: %s", f.usageHeader(), "FILES...")
+	}
+}
+
+func TestDisputeCustomCounter(t *testing.T) {
+	type example struct {
+		Verbose int  `options:"count"`
+		Quiet   bool `init:"q"`
+	}
+
+	var e example
+	agmt := NewEmptyArgumentFromStruct(&e)
+
+	err := agmt.DisputeCustom([]string{"-vvqv", "--verbose"}, false)
+	if err != nil {
+		t.Errorf("DisputeCustom was incorrect, expected: nil, got %v", err)
+	}
+
+	if e.Verbose != 4 || !e.Quiet {
+		t.Errorf("DisputeCustom was incorrect, got: %d, %t, expected: 4, true", e.Verbose, e.Quiet)
+	}
+
+	f, _ := agmt.NameExists("verbose")
+	if f.usageHeader() != "-v, --verbose" {
+		t.Errorf("usageHeader was incorrect, got: %s, expected: %s", f.usageHeader(), "-v, --verbose")
+	}
+}
+
+func TestDisputeCustomNegatable(t *testing.T) {
+	type example struct {
+		Color bool `options:"negatable"`
+		Cache bool `init:"a"`
+	}
+
+	e := example{Color: true, Cache: true}
+	agmt := NewEmptyArgumentFromStruct(&e)
+
+	err := agmt.DisputeCustom([]string{"--no-color", "--cache=no"}, false)
+	if err != nil {
+		t.Errorf("DisputeCustom was incorrect, expected: nil, got %v", err)
+	}
+
+	if e.Color || e.Cache {
+		t.Errorf("DisputeCustom was incorrect, got: %t, %t, expected: false, false", e.Color, e.Cache)
+	}
+
+	err = agmt.DisputeCustom([]string{"--color=1", "-a=TRUE"}, false)
+	if err != nil || !e.Color || !e.Cache {
+		t.Errorf("DisputeCustom was incorrect, got: %v, %t, %t, expected: nil, true, true", err, e.Color, e.Cache)
+	}
+
+	err = agmt.DisputeCustom([]string{"--no-cache"}, false)
+	if !errors.Is(err, ErrUnknownFlag) {
 		t.Errorf("DisputeCustom was incorrect, expected: ErrUnknownFlag, got %v", err)
 	}
+
+	err = agmt.DisputeCustom([]string{"--color=maybe"}, false)
+	if !errors.Is(err, ErrWrongType) {
+		t.Errorf("DisputeCustom was incorrect, expected: ErrWrongType, got %v", err)
+	}
+
+	f, _ := agmt.NameExists("color")
+	if f.usageHeader() != "-c, --[no-]color" {
+		t.Errorf("usageHeader was incorrect, got: %s, expected: %s", f.usageHeader(), "-c, --[no-]color")
+	}
+}
+
+func TestDefaults(t *testing.T) {
+	type example struct {
+		Port    int      `default:"8080" help:"port to listen on"`
+		Host    string   `default:"" help:"host to bind"`
+		Retries int      `default:"0" help:"number of retries"`
+		Tags    []string `default:"a, b"`
+	}
+
+	var e example
+	agmt := NewEmptyArgumentFromStruct(&e)
+	if e.Port != 8080 || len(e.Tags) != 2 || e.Tags[1] != "b" {
+		t.Errorf("NewEmptyArgumentFromStruct was incorrect, got: %d, %v, expected: 8080, [a b]", e.Port, e.Tags)
+	}
+
+	f, _ := agmt.NameExists("port")
+	if f.usageHelp("") != "port to listen on (default: 8080)" {
+		t.Errorf("usageHelp was incorrect, got: %s, expected: %s", f.usageHelp(""), "port to listen on (default: 8080)")
+	}
+
+	f, _ = agmt.NameExists("retries")
+	if f.usageHelp("") != "number of retries" {
+		t.Errorf("usageHelp was incorrect, got: %s, expected: %s", f.usageHelp(""), "number of retries")
+	}
+
+	var tName string
+	agmt.AddFlagFact("name", "a name", &tName).SetDefault("argue")
+	if tName != "argue" {
+		t.Errorf("SetDefault was incorrect, got: %s, expected: %s", tName, "argue")
+	}
+}
+
+func TestDisputeCustomEnv(t *testing.T) {
+	type example struct {
+		Port    int    `env:"ARGUE_TEST_PORT" options:"required"`
+		Host    string `default:"localhost"`
+		Verbose bool
+	}
+
+	var e example
+	agmt := NewEmptyArgumentFromStruct(&e)
+	agmt.EnvPrefix = "argue_test_"
+
+	t.Setenv("ARGUE_TEST_PORT", "9000")
+	t.Setenv("ARGUE_TEST_HOST", "example.com")
+	t.Setenv("ARGUE_TEST_VERBOSE", "yes")
+
+	err := agmt.DisputeCustom([]string{"--host", "127.0.0.1"}, false)
+	if err != nil {
+		t.Errorf("DisputeCustom was incorrect, expected: nil, got %v", err)
+	}
+
+	if e.Port != 9000 || e.Host != "127.0.0.1" || !e.Verbose {
+		t.Errorf("DisputeCustom was incorrect, got: %d, %s, %t, expected: 9000, 127.0.0.1, true", e.Port, e.Host, e.Verbose)
+	}
+
+	f, _ := agmt.NameExists("host")
+	if agmt.envName(f) != "ARGUE_TEST_HOST" {
+		t.Errorf("envName was incorrect, got: %s, expected: %s", agmt.envName(f), "ARGUE_TEST_HOST")
+	}
+
+	t.Setenv("ARGUE_TEST_PORT", "abc")
+	err = agmt.DisputeCustom(nil, false)
+	if !errors.Is(err, ErrWrongType) {
+		t.Errorf("DisputeCustom was incorrect, expected: ErrWrongType, got %v", err)
+	}
+}
+
+func TestDisputeCustomNoExit(t *testing.T) {
+	var tInt int
+	var stdout, stderr bytes.Buffer
+	var code = -1
+
+	agmt := NewArgument("This is a test of the argument library.", "2.0.0")
+	agmt.AddFlagFact("int", "this is an integer", &tInt)
+	agmt.SetOutput(&stdout, &stderr)
+	agmt.SetExitFunc(func(c int) { code = c })
+
+	err := agmt.DisputeCustom([]string{"--help"}, false)
+	if !errors.Is(err, ErrHelpRequested) || code != -1 || !strings.Contains(stdout.String(), "Usage:") {
+		t.Errorf("DisputeCustom was incorrect, got: %v, %d, expected: ErrHelpRequested, -1", err, code)
+	}
+
+	stdout.Reset()
+	err = agmt.DisputeCustom([]string{"--version"}, true)
+	if !errors.Is(err, ErrVersionRequested) || code != 0 || !strings.HasSuffix(stdout.String(), "2.0.0\n") {
+		t.Errorf("DisputeCustom was incorrect, got: %v, %d, expected: ErrVersionRequested, 0", err, code)
+	}
+
+	err = agmt.DisputeCustom([]string{"--int", "abc"}, true)
+	if !errors.Is(err, ErrWrongType) || code != 1 || !strings.HasPrefix(stderr.String(), "Error: --int") {
+		t.Errorf("DisputeCustom was incorrect, got: %v, %d, %q, expected: ErrWrongType, 1", err, code, stderr.String())
+	}
+}
+
+func TestParseError(t *testing.T) {
+	var tInt int
+	var tPos string
+
+	agmt := NewEmptyArgument()
+	agmt.AddFlagFact("int", "this is an integer", &tInt)
+	agmt.AddPositionalFact("pos", "this is a positional string", &tPos)
+
+	arguments := []string{"asdf", "--int", "abc"}
+	err := agmt.DisputeCustom(arguments, false)
+
+	var perr *ParseError
+	if !errors.As(err, &perr) {
+		t.Fatalf("DisputeCustom was incorrect, expected: *ParseError, got %T", err)
+	}
+
+	if !errors.Is(err, ErrWrongType) || perr.Fact == nil || perr.Fact.Name != "int" {
+		t.Errorf("DisputeCustom was incorrect, got: %v, %v, expected: ErrWrongType for int", perr.Kind, perr.Fact)
+	}
+
+	if perr.Token != "abc" || perr.Index != 2 || perr.Err == nil {
+		t.Errorf("DisputeCustom was incorrect, got: %q at %d, expected: \"abc\" at 2", perr.Token, perr.Index)
+	}
+
+	err = agmt.DisputeCustom([]string{"asdf", "-qi5"}, false)
+	if !errors.As(err, &perr) || perr.Token != "-qi5" || perr.Index != 1 || perr.Fact != nil {
+		t.Errorf("DisputeCustom was incorrect, got: %v, expected: unknown flag -q in -qi5 at 1", err)
+	}
+}
+
+func TestDisputeCustomCollectErrors(t *testing.T) {
+	var tInt int
+	var tName string
+	var tPos string
+	var stderr bytes.Buffer
+	var code int
+
+	agmt := NewEmptyArgument()
+	agmt.AddFlagFact("int", "this is an integer", &tInt)
+	agmt.AddFlagFact("name", "this is a string", &tName).SetRequired(true)
+	agmt.AddPositionalFact("pos", "this is a positional string", &tPos)
+	agmt.SetOutput(nil, &stderr)
+	agmt.SetExitFunc(func(c int) { code = c })
+	agmt.CollectErrors = true
+
+	err := agmt.DisputeCustom([]string{"--bogus", "--int", "abc"}, true)
+	for _, kind := range []error{ErrUnknownFlag, ErrMissingFlag, ErrMissingPositionals, ErrWrongType} {
+		if !errors.Is(err, kind) {
+			t.Errorf("DisputeCustom was incorrect, expected: %v within %v", kind, err)
+		}
+	}
+
+	if len(ParseErrors(err)) != 4 {
+		t.Errorf("ParseErrors was incorrect, got: %d errors, expected: %d errors", len(ParseErrors(err)), 4)
+	}
+
+	if code != 1 || strings.Count(stderr.String(), "Error: ") != 4 {
+		t.Errorf("DisputeCustom was incorrect, got: %d, %q, expected four errors and exit code 1", code, stderr.String())
+	}
+}
+
+func TestUsageString(t *testing.T) {
+	var tInt int
+	var tName string
+	var tFiles []string
+
+	agmt := NewArgument("This is a test of the argument library.", "2.0.0")
+	agmt.AddFlagFact("int", "this is an integer", &tInt).SetDefault("5")
+	agmt.AddFlagFact("name", "this is a string", &tName).SetEnv("TEST_NAME")
+	agmt.AddPositionalFact("files", "these are files", &tFiles)
+	agmt.SetWidth(-1)
+
+	usage := strings.Replace(agmt.UsageString(), os.Args[0], "test", 1)
+	expected := binaryName() + ` 2.0.0
+This is a test of the argument library.
+
+Usage: test [--int VALUE] [--name VALUE] FILES...
+
+Positional arguments:
+  FILES...            these are files
+
+Flags:
+  -i, --int VALUE     this is an integer (default: 5)
+  -n, --name VALUE    this is a string [env: TEST_NAME]
+  -h, --help          display this help and exit
+  -v, --version       display version and exit
+`
+	if usage != expected {
+		t.Errorf("UsageString was incorrect, got:\n%s\nexpected:\n%s", usage, expected)
+	}
+}
+
+func TestUsageWrapping(t *testing.T) {
+	defer func(name string) { os.Args[0] = name }(os.Args[0])
+	os.Args[0] = "test"
+
+	var tInt int
+	var tName string
+	var tFiles []string
+
+	agmt := NewEmptyArgument()
+	agmt.AddFlagFact("int", "the number of times that the operation is repeated before exiting", &tInt)
+	agmt.AddFlagFact("name", "a name", &tName)
+	agmt.AddPositionalFact("files", "these are files", &tFiles)
+	agmt.SetWidth(44)
+
+	expected := `Usage: test [--int VALUE] [--name VALUE]
+            FILES...
+
+Positional arguments:
+  FILES...            these are files
+
+Flags:
+  -i, --int VALUE     the number of times
+                      that the operation is
+                      repeated before
+                      exiting
+  -n, --name VALUE    a name
+  -h, --help          display this help and
+                      exit
+`
+	if usage := agmt.UsageString(); usage != expected {
+		t.Errorf("UsageString was incorrect, got:\n%s\nexpected:\n%s", usage, expected)
+	}
+}
+
+func TestUsageTemplate(t *testing.T) {
+	var tInt int
+	var tName string
+
+	agmt := NewArgument("A custom template.", "1.0.0")
+	agmt.AddFlagFact("int", "this is an integer", &tInt)
+	agmt.AddFlagFact("name", "this is a string", &tName)
+	agmt.SetUsageTemplate(`{{.Description}}
+OPTIONS
+{{range .Flags}}{{if .Fact.Required}}*{{end}}{{.Header}}: {{.Help}}
+{{end}}`)
+
+	expected := `A custom template.
+OPTIONS
+-i, --int VALUE: this is an integer
+-n, --name VALUE: this is a string
+-h, --help: display this help and exit
+-v, --version: display version and exit
+`
+	if usage := agmt.UsageString(); usage != expected {
+		t.Errorf("UsageString was incorrect, got:\n%s\nexpected:\n%s", usage, expected)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("SetUsageTemplate did not panic on an invalid template")
+		}
+	}()
+	agmt.SetUsageTemplate("{{.Flags")
+}
+
+func TestUsageGroups(t *testing.T) {
+	defer func(name string) { os.Args[0] = name }(os.Args[0])
+	os.Args[0] = "test"
+
+	var ts struct {
+		Verbose bool   `help:"be verbose"`
+		Port    int    `help:"port to listen on" group:"Networking"`
+		Level   string `help:"log level" group:"Logging"`
+		Address string `help:"address to bind" group:"Networking"`
+	}
+
+	agmt := NewEmptyArgumentFromStruct(&ts)
+	agmt.SetWidth(-1)
+
+	expected := `Usage: test [--address VALUE] [--level VALUE] [--port VALUE] [--verbose]
+
+Flags:
+  -v, --verbose          be verbose
+  -h, --help             display this help and exit
+
+Networking:
+  -a, --address VALUE    address to bind
+  -p, --port VALUE       port to listen on
+
+Logging:
+  -l, --level VALUE      log level
+`
+	if usage := agmt.UsageString(); usage != expected {
+		t.Errorf("UsageString was incorrect, got:\n%s\nexpected:\n%s", usage, expected)
+	}
+
+	agmt.SetGroupOrder("Logging")
+	usage := agmt.UsageString()
+	if strings.Index(usage, "Logging:") > strings.Index(usage, "Networking:") {
+		t.Errorf("UsageString was incorrect, got:\n%s\nexpected Logging before Networking", usage)
+	}
+}
+
+func TestHiddenDeprecated(t *testing.T) {
+	var ts struct {
+		Debug bool   `options:"hidden"`
+		Old   string `deprecated:"use --name instead"`
+		Name  string
+	}
+
+	var stderr bytes.Buffer
+	agmt := NewEmptyArgumentFromStruct(&ts)
+	agmt.SetOutput(nil, &stderr)
+	agmt.SetWidth(-1)
+
+	usage := agmt.UsageString()
+	if strings.Contains(usage, "--debug") {
+		t.Errorf("UsageString was incorrect, got:\n%s\nexpected no --debug", usage)
+	}
+
+	if !strings.Contains(usage, "--old VALUE     (deprecated)\n") {
+		t.Errorf("UsageString was incorrect, got:\n%s\nexpected --old to be marked deprecated", usage)
+	}
+
+	err := agmt.DisputeCustom([]string{"--debug", "--old", "a", "--old", "b"}, false)
+	if err != nil {
+		t.Fatalf("DisputeCustom was incorrect, got: %v, expected: nil", err)
+	}
+
+	if !ts.Debug || ts.Old != "b" {
+		t.Errorf("DisputeCustom was incorrect, got: %v %q, expected: true \"b\"", ts.Debug, ts.Old)
+	}
+
+	expected := "Warning: flag --old is deprecated, use --name instead\n"
+	if stderr.String() != expected {
+		t.Errorf("Warning was incorrect, got: %q, expected: %q", stderr.String(), expected)
+	}
+
+	var perr *ParseError
+	err = agmt.DisputeCustom([]string{"--debu"}, false)
+	if !errors.As(err, &perr) || len(perr.Suggestions) != 0 {
+		t.Errorf("DisputeCustom was incorrect, got: %v, expected no suggestions", err)
+	}
 }
@@ -2,34 +2,68 @@ package argue
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"strings"
 )
 
-func printFact(w int, s int, f Fact) {
-	header := "  " + f.usageHeader()
-	extra := w - len(header)
-	space := strings.Repeat(" ", s+extra)
-	fmt.Println(header + space + f.Help)
-}
-
 // PrintError accepts a message and will print it
 // as an error message along with the received
 // Argument's usage line. This will exit the program
-// with an error code of 1.
+// with an error code of 1 using the exit function
+// of the Argument.
 func (a Argument) PrintError(msg string) {
-	fmt.Printf("Error: %v\n", msg)
-	fmt.Printf("Run \"%v", os.Args[0])
+	a.printErrors([]string{msg})
+}
+
+// printErrors prints each of the passed messages as
+// an error message, followed by the received
+// Argument's usage line, and exits the program with
+// an error code of 1.
+func (a Argument) printErrors(msgs []string) {
+	out := a.errorWriter()
+	for _, msg := range msgs {
+		fmt.Fprintf(out, "Error: %v\n", msg)
+	}
+	fmt.Fprintf(out, "Run \"%v", os.Args[0])
 	if a.commandSuffix != "" {
-		fmt.Print(" " + a.commandSuffix)
+		fmt.Fprint(out, " "+a.commandSuffix)
 	}
-	fmt.Print(" --help\" to see usage information\n")
-	os.Exit(1)
+	fmt.Fprint(out, " --help\" to see usage information\n")
+	a.exit(1)
 }
 
 // PrintUsage writes the usage information of the
-// received Argument to the standard output.
+// received Argument to its output writer, which is
+// the standard output by default.
 func (a Argument) PrintUsage() {
+	a.WriteUsage(a.outputWriter())
+}
+
+// WriteUsage writes the usage information of the
+// received Argument to the passed writer, rendered
+// with its usage template.
+func (a Argument) WriteUsage(out io.Writer) {
+	tmpl := a.usageTemplate
+	if tmpl == nil {
+		tmpl = defaultUsageTemplate
+	}
+
+	executeUsageTemplate(out, tmpl, a.usageData(a.outputWidth(out)))
+}
+
+// SetUsageTemplate sets the text/template used to
+// render the usage information of the received
+// argument. The template is executed with a
+// UsageData value. This will panic if the template
+// can not be parsed.
+func (a *Argument) SetUsageTemplate(text string) {
+	a.usageTemplate = parseUsageTemplate(text)
+}
+
+// usageData returns the usage information of the
+// received Argument, wrapped to the passed width.
+func (a Argument) usageData(width int) UsageData {
 	spacing := 4
 
 	// Add help and version fact definitons and a dummy
@@ -37,73 +71,68 @@ func (a Argument) PrintUsage() {
 	var dummy bool
 	helpFact := NewFact("display this help and exit", "help", byte("h"[0]), false, false, &dummy)
 	versionFact := NewFact("display version and exit", "version", byte("v"[0]), false, false, &dummy)
-	factBank := append(a.Facts(), &helpFact)
+	defaultFacts := []*Fact{&helpFact}
 	if a.ShowVersion {
-		factBank = append(factBank, &versionFact)
+		defaultFacts = append(defaultFacts, &versionFact)
 	}
 
-	// Check which fact has the longest length to use as
-	// a baseline for spacing
 	a.SortFlagFacts()
-	width := 0
-	for _, f := range factBank {
-		l := len(f.usageHeader()) + 2
-		if l > width {
-			width = l
-		}
+	noEnv := func(*Fact) string { return "" }
+	data := UsageData{
+		Name:        binaryName(),
+		Binary:      os.Args[0],
+		Command:     a.commandSuffix,
+		Version:     a.Version,
+		ShowVersion: a.ShowVersion,
+		Description: a.Description,
+		ShowDesc:    a.ShowDesc,
+		Positionals: factEntries(a.PositionalFacts, noEnv),
+		Width:       width,
 	}
 
-	if a.ShowVersion {
-		a.PrintVersion()
-	}
-
-	if a.ShowDesc {
-		fmt.Println(a.Description + "\n")
-	}
+	ungrouped, groups := a.flagGroups()
+	data.Flags = append(factEntries(ungrouped, a.envName), factEntries(defaultFacts, noEnv)...)
+	data.Groups = groupEntries(groups, a.envName)
+	alignEntries(spacing, append(groupFlags(data.Groups), data.Positionals, data.Flags)...)
 
-	// Print usage line
-	fmt.Printf("Usage: %s", os.Args[0])
+	// Build usage line
+	prefix := "Usage: " + os.Args[0]
 	if a.commandSuffix != "" {
-		fmt.Print(" " + a.commandSuffix)
+		prefix += " " + a.commandSuffix
 	}
+	var items []string
 	for _, f := range a.FlagFacts {
-		if f.Type == FactTypeBool {
-			fmt.Printf(" [--%s]", f.Name)
-		} else {
-			fmt.Printf(" [--%s VALUE]", f.Name)
+		if !f.Hidden {
+			items = append(items, f.usageSummary())
 		}
 	}
 
 	for _, f := range a.PositionalFacts {
-		fmt.Printf(" %s", UpperFactName(f.Name))
-	}
-	fmt.Println()
-
-	// Display positional facts
-	if len(a.PositionalFacts) > 0 {
-		fmt.Println()
-		fmt.Println("Positional arguments:")
-		for _, f := range a.PositionalFacts {
-			printFact(width, spacing, *f)
-		}
+		items = append(items, f.usageHeader())
 	}
+	data.Usage = usageLine(prefix, items, width)
 
-	fmt.Println()
-	fmt.Println("Flags:")
-	for _, f := range a.FlagFacts {
-		printFact(width, spacing, *f)
-	}
+	return data
+}
 
-	// Print default fact information
-	printFact(width, spacing, helpFact)
-	if a.ShowVersion {
-		printFact(width, spacing, versionFact)
-	}
+// UsageString returns the usage information of the
+// received Argument as a string.
+func (a Argument) UsageString() string {
+	var b strings.Builder
+	a.WriteUsage(&b)
+	return b.String()
 }
 
 // PrintVersion writes the version of the program to
-// the standard output in the form of "<name>
-// <version>"
+// the output writer of the Argument in the form of
+// "<name> <version>"
 func (a Argument) PrintVersion() {
-	fmt.Printf("%v %v\n", binaryName(), a.Version)
+	a.WriteVersion(a.outputWriter())
+}
+
+// WriteVersion writes the version of the program
+// specified by the received Argument to the passed
+// writer.
+func (a Argument) WriteVersion(out io.Writer) {
+	fmt.Fprintf(out, "%v %v\n", binaryName(), a.Version)
 }
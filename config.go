@@ -0,0 +1,305 @@
+package argue
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// configValues maps the names of facts to the values
+// found for them in a configuration file.
+type configValues map[string][]string
+
+// readConfigFile reads the configuration file at the
+// passed path and returns its values by section. The
+// values outside of any section are stored under the
+// empty string. Files ending in ".json" are read as
+// JSON, and all others are read as INI.
+func readConfigFile(path string) (map[string]configValues, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.ToLower(filepath.Ext(path)) == ".json" {
+		return parseJSONConfig(data)
+	}
+
+	return parseINIConfig(data)
+}
+
+// parseJSONConfig parses a JSON object into sections
+// of values. Nested objects are treated as sections
+// named after the path of keys that leads to them,
+// joined by dots, as in "cluster.node".
+func parseJSONConfig(data []byte) (map[string]configValues, error) {
+	var object map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&object); err != nil {
+		return nil, err
+	}
+
+	sections := make(map[string]configValues)
+	if err := addJSONSection(sections, "", object); err != nil {
+		return nil, err
+	}
+
+	return sections, nil
+}
+
+// addJSONSection adds the values of the passed JSON
+// object to the section with the passed name, and
+// its nested objects to sections of their own.
+func addJSONSection(sections map[string]configValues, name string, object map[string]interface{}) error {
+	sections[name] = make(configValues)
+	for k, v := range object {
+		if nested, ok := v.(map[string]interface{}); ok {
+			if err := addJSONSection(sections, sectionPath(name, k), nested); err != nil {
+				return err
+			}
+			continue
+		}
+
+		values, err := jsonValues(k, v)
+		if err != nil {
+			return err
+		}
+		sections[name][StandardizeFactName(k)] = values
+	}
+
+	return nil
+}
+
+// sectionPath returns the name of the configuration
+// section for the passed command within the section
+// with the passed name.
+func sectionPath(section string, command string) string {
+	if section == "" {
+		return StandardizeFactName(command)
+	}
+
+	return section + "." + StandardizeFactName(command)
+}
+
+// jsonValues converts a decoded JSON value into its
+// text form. Arrays provide one value per element.
+func jsonValues(key string, v interface{}) ([]string, error) {
+	switch t := v.(type) {
+	case nil:
+		return nil, nil
+	case []interface{}:
+		var values []string
+		for _, e := range t {
+			switch e.(type) {
+			case []interface{}, map[string]interface{}:
+				return nil, fmt.Errorf("key %q contains a nested value", key)
+			}
+			values = append(values, fmt.Sprint(e))
+		}
+		return values, nil
+	case map[string]interface{}:
+		return nil, fmt.Errorf("key %q contains a nested value", key)
+	}
+
+	return []string{fmt.Sprint(v)}, nil
+}
+
+// parseINIConfig parses INI formatted data into
+// sections of values. Sections of nested commands
+// are named after the path of commands, joined by
+// dots, as in "[cluster.node]". Repeated keys
+// provide multiple values, and lines starting with
+// "#" or ";" are ignored.
+func parseINIConfig(data []byte) (map[string]configValues, error) {
+	sections := map[string]configValues{"": make(configValues)}
+	section := ""
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") || strings.HasPrefix(text, ";") {
+			continue
+		}
+
+		// Start a new section
+		if strings.HasPrefix(text, "[") && strings.HasSuffix(text, "]") {
+			section = StandardizeFactName(strings.TrimSpace(text[1 : len(text)-1]))
+			if _, ok := sections[section]; !ok {
+				sections[section] = make(configValues)
+			}
+			continue
+		}
+
+		i := strings.Index(text, "=")
+		if i < 0 {
+			return nil, fmt.Errorf("line %d is not a key and value pair", line)
+		}
+
+		key := StandardizeFactName(strings.TrimSpace(text[:i]))
+		value := strings.TrimSpace(text[i+1:])
+		if len(value) > 1 && value[0] == '"' && value[len(value)-1] == '"' {
+			value = value[1 : len(value)-1]
+		}
+
+		sections[section][key] = append(sections[section][key], value)
+	}
+
+	return sections, scanner.Err()
+}
+
+// SetConfigFile sets the path of a configuration
+// file to load values from when the received
+// argument is disputed. Values in the file are keyed
+// by fact name and are overridden by environment
+// variables and the command-line. If the file does
+// not exist, it is ignored.
+func (a *Argument) SetConfigFile(path string) {
+	a.configFile = path
+}
+
+// AddConfigFact adds a "--config FILE" flag fact to
+// the received argument. If it is provided, the file
+// it names is loaded in place of the one set with
+// SetConfigFile and must exist.
+func (a *Argument) AddConfigFact() *Fact {
+	var path string
+	fact := a.AddFlagFact("config", "load configuration from FILE", &path)
+	fact.config = true
+	fact.valueName = "FILE"
+	return fact
+}
+
+// configFact returns the fact added by AddConfigFact
+// if it exists.
+func (a Argument) configFact() (*Fact, bool) {
+	for _, f := range a.FlagFacts {
+		if f.config {
+			return f, true
+		}
+	}
+
+	return nil, false
+}
+
+// loadConfig loads the configuration file that
+// applies to the passed flags, skipping the facts
+// that were provided on the command-line. Facts that
+// receive a value are added to found.
+func (a Argument) loadConfig(flags []flagToken, provided map[*Fact]bool, found map[*Fact]bool) *ParseError {
+	path := a.configFile
+	explicit := false
+	if cf, ok := a.configFact(); ok {
+		for _, t := range flags {
+			f, _ := a.dressedFact(t.key)
+			if s, ok := t.value.(string); ok && f == cf {
+				path = s
+				explicit = true
+			}
+		}
+	}
+
+	if path == "" {
+		return nil
+	}
+
+	sections, err := readConfigFile(path)
+	if err != nil {
+		if os.IsNotExist(err) && !explicit {
+			return nil
+		}
+
+		perr := newParseError(ErrConfigFile, fmt.Sprintf("configuration file %s: %v", path, err))
+		perr.Err = err
+		return perr
+	}
+
+	// Apply keys in a consistent order
+	values := sections[a.configSection]
+	var keys []string
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		f, ok := a.NameExists(key)
+		if !ok || f.Positional {
+			return newParseError(ErrConfigFile, fmt.Sprintf("configuration file %s: unknown key %q", path, key))
+		}
+
+		if f.config || provided[f] || len(values[key]) == 0 {
+			continue
+		}
+
+		err := setConfigValues(f, values[key])
+		if err != nil {
+			perr := newParseError(ErrConfigFile, fmt.Sprintf("configuration file %s: key %q %v", path, key, err))
+			perr.Fact = f
+			perr.Err = err
+			return perr
+		}
+
+		found[f] = true
+	}
+
+	return nil
+}
+
+// setConfigValues assigns the values found in a
+// configuration file to the passed fact.
+func setConfigValues(f *Fact, values []string) error {
+	if len(values) == 1 || !f.Type.slice() {
+		return f.setText(values[len(values)-1])
+	}
+
+	f.clear()
+	for _, v := range values {
+		if err := f.SetValue(v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SetConfigFile sets the path of a configuration
+// file to load values from when the received Lawyer
+// takes a case. Values outside of any section apply
+// to the Lawyer's facts, and values within a section
+// apply to the sub-argument of the same name. The
+// sections of nested commands are named after their
+// path, as in "cluster.node.add". If the file does
+// not exist, it is ignored.
+func (l *Lawyer) SetConfigFile(path string) {
+	l.defaultArgument.SetConfigFile(path)
+}
+
+// AddConfigFact adds a "--config FILE" flag fact to
+// the received Lawyer. If it is provided, the file
+// it names is loaded in place of the one set with
+// SetConfigFile and must exist.
+func (l *Lawyer) AddConfigFact() *Fact {
+	if _, ok := l.defaultArgument.NameExists("config"); ok {
+		panic("argument: fact name already exits within this lawyer")
+	}
+
+	return l.defaultArgument.AddConfigFact()
+}
+
+// configFile returns the path of the configuration
+// file that the received Lawyer loaded, if any.
+func (l Lawyer) configFile() string {
+	if f, ok := l.defaultArgument.configFact(); ok {
+		if path := *f.Value.(*string); path != "" {
+			return path
+		}
+	}
+
+	return l.defaultArgument.configFile
+}
@@ -0,0 +1,147 @@
+package argue
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeConfig(t *testing.T, name string, contents string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestConfigFile(t *testing.T) {
+	type example struct {
+		Port    int `options:"required"`
+		Host    string
+		Include []string
+	}
+
+	var e example
+	agmt := NewEmptyArgumentFromStruct(&e)
+	agmt.AddConfigFact()
+
+	path := writeConfig(t, "app.json", `{"port": 8080, "host": "example.com", "include": ["a", "b"]}`)
+	err := agmt.DisputeCustom([]string{"--config", path, "--host", "localhost"}, false)
+	if err != nil {
+		t.Errorf("DisputeCustom was incorrect, expected: nil, got %v", err)
+	}
+
+	if e.Port != 8080 || e.Host != "localhost" || len(e.Include) != 2 {
+		t.Errorf("DisputeCustom was incorrect, got: %d, %s, %v, expected: 8080, localhost, [a b]", e.Port, e.Host, e.Include)
+	}
+
+	path = writeConfig(t, "app.ini", "# comment\nport = 9090\ninclude = c\ninclude = d\n")
+	agmt.SetConfigFile(path)
+	err = agmt.DisputeCustom(nil, false)
+	if err != nil {
+		t.Errorf("DisputeCustom was incorrect, expected: nil, got %v", err)
+	}
+
+	if e.Port != 9090 || len(e.Include) != 2 || e.Include[1] != "d" {
+		t.Errorf("DisputeCustom was incorrect, got: %d, %v, expected: 9090, [c d]", e.Port, e.Include)
+	}
+
+	path = writeConfig(t, "bad.ini", "port = abc\n")
+	err = agmt.DisputeCustom([]string{"--config=" + path}, false)
+	if !errors.Is(err, ErrConfigFile) {
+		t.Errorf("DisputeCustom was incorrect, expected: ErrConfigFile, got %v", err)
+	}
+
+	err = agmt.DisputeCustom([]string{"--config", filepath.Join(t.TempDir(), "missing.ini")}, false)
+	if !errors.Is(err, ErrConfigFile) {
+		t.Errorf("DisputeCustom was incorrect, expected: ErrConfigFile, got %v", err)
+	}
+}
+
+func TestLawyerConfigFile(t *testing.T) {
+	type command struct {
+		Name string
+	}
+
+	var c command
+	var debug bool
+	law := NewEmptyLawyer()
+	law.AddFact("debug", "enable debugging", &debug)
+	law.AddConfigFact()
+	law.AddArgumentFromStruct("greet", "greet someone", &c)
+
+	path := writeConfig(t, "app.ini", "debug = true\n\n[greet]\nname = world\n")
+	err := law.TakeCustomCase([]string{"--config", path, "greet"}, false)
+	if err != nil {
+		t.Errorf("TakeCustomCase was incorrect, expected: nil, got %v", err)
+	}
+
+	if !debug || c.Name != "world" {
+		t.Errorf("TakeCustomCase was incorrect, got: %t, %s, expected: true, world", debug, c.Name)
+	}
+}
+
+func TestSubArgumentConfigFile(t *testing.T) {
+	type command struct {
+		Name string
+	}
+
+	var c, n command
+	arg := NewEmptyArgumentFromStruct(&c)
+	arg.SetConfigFile(writeConfig(t, "run.ini", "name = fromfile\n"))
+
+	node := NewEmptyLawyer()
+	node.SetConfigFile(writeConfig(t, "node.ini", "[add]\nname = nested\n"))
+	node.AddArgumentFromStruct("add", "add a node", &n)
+
+	law := NewEmptyLawyer()
+	law.AddArgument("run", "run something", arg)
+	law.AddLawyer("node", "manage nodes", node)
+
+	if err := law.TakeCustomCase([]string{"run"}, false); err != nil || c.Name != "fromfile" {
+		t.Errorf("TakeCustomCase was incorrect, got: %q %v, expected: \"fromfile\" <nil>", c.Name, err)
+	}
+
+	if err := law.TakeCustomCase([]string{"node", "add"}, false); err != nil || n.Name != "nested" {
+		t.Errorf("TakeCustomCase was incorrect, got: %q %v, expected: \"nested\" <nil>", n.Name, err)
+	}
+}
+
+func TestNestedLawyerConfigFile(t *testing.T) {
+	type command struct {
+		Name string
+	}
+
+	var top, nested command
+	var region string
+	node := NewEmptyLawyer()
+	node.AddArgumentFromStruct("add", "add a node", &nested)
+
+	cluster := NewEmptyLawyer()
+	cluster.AddFact("region", "the cluster's region", &region)
+	cluster.AddLawyer("node", "manage nodes", node)
+
+	law := NewEmptyLawyer()
+	law.AddConfigFact()
+	law.AddArgumentFromStruct("add", "add an item", &top)
+	law.AddLawyer("cluster", "manage clusters", cluster)
+
+	files := []string{
+		writeConfig(t, "app.ini", "[add]\nname = top\n\n[cluster]\nregion = west\n\n[cluster.node.add]\nname = nested\n"),
+		writeConfig(t, "app.json", `{"add": {"name": "top"}, "cluster": {"region": "west", "node": {"add": {"name": "nested"}}}}`),
+	}
+
+	for _, path := range files {
+		top, nested, region = command{}, command{}, ""
+		if err := law.TakeCustomCase([]string{"--config", path, "add"}, false); err != nil || top.Name != "top" {
+			t.Errorf("TakeCustomCase was incorrect for %s, got: %q %v, expected: \"top\" <nil>", path, top.Name, err)
+		}
+
+		err := law.TakeCustomCase([]string{"--config", path, "cluster", "node", "add"}, false)
+		if err != nil || nested.Name != "nested" || region != "west" {
+			t.Errorf("TakeCustomCase was incorrect for %s, got: %q %q %v, expected: \"nested\" \"west\" <nil>", path, nested.Name, region, err)
+		}
+	}
+}
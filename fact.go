@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
+	"strings"
 
 	"github.com/rburmorrison/go-argue/internal/mirror"
 )
@@ -19,7 +20,18 @@ type Fact struct {
 	Initial    byte
 	Positional bool
 	Required   bool
+	Negatable  bool
+	Minimum    int
+	Default    string
+	Env        string
+	Group      string
+	Hidden     bool
+	Deprecated string
 	Value      interface{}
+
+	config    bool
+	valueName string
+	order     int
 }
 
 // NewFact returns a new fact with the given
@@ -82,6 +94,10 @@ func (f *Fact) SetPositional(p bool) *Fact {
 		panic("argue: a fact of type bool can not be positional")
 	}
 
+	if p && f.Type == FactTypeCounter {
+		panic("argue: a counter fact can not be positional")
+	}
+
 	f.Positional = p
 	return f
 }
@@ -93,13 +109,217 @@ func (f *Fact) SetRequired(r bool) *Fact {
 	return f
 }
 
+// SetNegatable accepts a bool and sets the Negatable
+// property of the received fact to that bool.
+// Negatable facts may be turned off by prefixing
+// their name with "no-", as in "--no-name". Only
+// facts of type bool may be negatable.
+func (f *Fact) SetNegatable(n bool) *Fact {
+	if n && f.Type != FactTypeBool {
+		panic("argue: only a fact of type bool can be negatable")
+	}
+
+	f.Negatable = n
+	return f
+}
+
+// SetCounter accepts a bool and, if true, turns the
+// received fact into a counter that increments each
+// time it is provided instead of taking a value.
+// Only facts of type int may be counters.
+func (f *Fact) SetCounter(c bool) *Fact {
+	if !c {
+		if f.Type == FactTypeCounter {
+			f.Type = FactTypeInt
+		}
+
+		return f
+	}
+
+	if f.Type != FactTypeInt && f.Type != FactTypeCounter {
+		panic("argue: only a fact of type int can be a counter")
+	}
+
+	if f.Positional {
+		panic("argue: a counter fact can not be positional")
+	}
+
+	f.Type = FactTypeCounter
+	return f
+}
+
+// SetMinimum accepts the minimum number of values
+// that a variadic positional fact must receive and
+// marks the received fact as required if it is
+// above zero.
+func (f *Fact) SetMinimum(n int) *Fact {
+	f.Minimum = n
+	f.Required = n > 0
+	return f
+}
+
+// SetDefault accepts a default value in its text
+// form and assigns it to the received fact. The text
+// is parsed the same way as a value provided on the
+// command-line. Slice facts accept multiple values
+// separated by commas.
+func (f *Fact) SetDefault(d string) *Fact {
+	err := f.setText(d)
+	if err != nil {
+		panic("argue: default value for " + f.Name + " " + err.Error())
+	}
+
+	f.Default = d
+	return f
+}
+
+// SetEnv accepts the name of an environment
+// variable and sets the Env property of the received
+// fact to that name. If the fact is not provided on
+// the command-line, its value is taken from the
+// environment variable instead.
+func (f *Fact) SetEnv(e string) *Fact {
+	if e != "" && f.Positional {
+		panic("argue: a positional fact can not use an environment variable")
+	}
+
+	f.Env = e
+	return f
+}
+
+// SetGroup accepts the title of a group and sets the
+// Group property of the received fact to that title.
+// Flag facts that share a group are shown together
+// under the title in usage information.
+func (f *Fact) SetGroup(g string) *Fact {
+	if g != "" && f.Positional {
+		panic("argue: a positional fact can not belong to a group")
+	}
+
+	f.Group = g
+	return f
+}
+
+// SetHidden accepts a bool and sets the Hidden
+// property of the received fact to that bool. Hidden
+// facts work as usual but are left out of usage
+// information and suggestions. Only flag facts may
+// be hidden.
+func (f *Fact) SetHidden(h bool) *Fact {
+	if h && f.Positional {
+		panic("argue: a positional fact can not be hidden")
+	}
+
+	f.Hidden = h
+	return f
+}
+
+// SetDeprecated accepts a message, such as the fact
+// to use instead, and marks the received fact as
+// deprecated. Using a deprecated fact prints a
+// warning with the message. An empty message removes
+// the deprecation.
+func (f *Fact) SetDeprecated(msg string) *Fact {
+	f.Deprecated = msg
+	return f
+}
+
 // SetValue accepts a value and attempts to assign
 // the Value property of the received fact it's
 // parsed value. An error will be returned if that
 // is not possible.
 func (f *Fact) SetValue(v interface{}) error {
 	val := reflect.ValueOf(f.Value).Elem()
-	switch f.Type {
+
+	// Slices append each value that they receive
+	if f.Type.slice() {
+		elem := reflect.New(val.Type().Elem()).Elem()
+		err := setValue(elem, f.Type.elem(), v)
+		if err != nil {
+			return err
+		}
+
+		val.Set(reflect.Append(val, elem))
+		return nil
+	}
+
+	return setValue(val, f.Type, v)
+}
+
+// setText parses the passed text and assigns it to
+// the received fact, replacing any existing value.
+// Slice facts split the text into values separated
+// by commas.
+func (f *Fact) setText(s string) error {
+	if !f.Type.accumulates() {
+		return f.SetValue(s)
+	}
+
+	f.clear()
+	if !f.Type.slice() {
+		return f.SetValue(s)
+	}
+
+	for _, v := range strings.Split(s, ",") {
+		err := f.SetValue(strings.TrimSpace(v))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// hasDefault returns true if the received fact has
+// a default value other than the zero value of its
+// type.
+func (f Fact) hasDefault() bool {
+	if f.Default == "" {
+		return false
+	}
+
+	// Parse the default into a new variable so that the
+	// current value is left alone
+	f.Value = reflect.New(reflect.TypeOf(f.Value).Elem()).Interface()
+	if f.setText(f.Default) != nil {
+		return false
+	}
+
+	return !reflect.ValueOf(f.Value).Elem().IsZero()
+}
+
+// usageHelp returns the help text of a fact as it
+// should appear in the usage output, along with the
+// environment variable that it falls back on, if
+// any.
+func (f Fact) usageHelp(env string) string {
+	help := f.Help
+	if f.hasDefault() {
+		help = fmt.Sprintf("%s (default: %s)", help, f.Default)
+	}
+
+	if env != "" {
+		help = fmt.Sprintf("%s [env: %s]", help, env)
+	}
+
+	if f.Deprecated != "" {
+		help += " (deprecated)"
+	}
+
+	return strings.TrimSpace(help)
+}
+
+// clear resets the value of the received fact to
+// the zero value of its type.
+func (f *Fact) clear() {
+	val := reflect.ValueOf(f.Value).Elem()
+	val.Set(reflect.Zero(val.Type()))
+}
+
+// setValue parses the passed value according to the
+// passed FactType and assigns it to val.
+func setValue(val reflect.Value, t FactType, v interface{}) error {
+	switch t {
 	case FactTypeString:
 		s, ok := v.(string)
 		if !ok {
@@ -109,9 +329,23 @@ func (f *Fact) SetValue(v interface{}) error {
 	case FactTypeBool:
 		b, ok := v.(bool)
 		if !ok {
-			return errors.New("requires a boolean value")
+			s, _ := v.(string)
+			parsed, err := parseBool(s)
+			if err != nil {
+				return errors.New("requires a boolean value")
+			}
+			b = parsed
 		}
 		val.SetBool(b)
+	case FactTypeCounter:
+		// Counters increment each time they are provided,
+		// but may still be set explicitly
+		if _, ok := v.(bool); ok {
+			val.SetInt(val.Int() + 1)
+			break
+		}
+
+		fallthrough
 	case FactTypeInt:
 		fallthrough
 	case FactTypeInt64:
@@ -125,11 +359,11 @@ func (f *Fact) SetValue(v interface{}) error {
 		fallthrough
 	case FactTypeUInt64:
 		s := v.(string)
-		i, err := strconv.Atoi(s)
+		i, err := strconv.ParseUint(s, 10, 64)
 		if err != nil {
 			return errors.New("requires an non-negative integer value")
 		}
-		val.SetUint(uint64(i))
+		val.SetUint(i)
 	case FactTypeFloat32:
 		s := v.(string)
 		fl, err := strconv.ParseFloat(s, 32)
@@ -153,20 +387,68 @@ func (f *Fact) SetValue(v interface{}) error {
 // the argument.PrintUsage function.
 func (f Fact) usageHeader() string {
 	if f.Positional {
-		return fmt.Sprintf("%s", UpperFactName(f.Name))
+		if f.Type.slice() {
+			return UpperFactName(f.Name) + "..."
+		}
+
+		return UpperFactName(f.Name)
 	}
 
 	var s string
 	if f.Initial == 0 {
-		s = fmt.Sprintf("%s", f.DressedName())
+		s = fmt.Sprintf("%s", f.usageName())
 	} else {
-		s = fmt.Sprintf("%s, %s", f.DressedInitial(), f.DressedName())
+		s = fmt.Sprintf("%s, %s", f.DressedInitial(), f.usageName())
+	}
+
+	return s + f.usageValue()
+}
+
+// usageName returns the dressed name of a flag fact
+// as it should appear in the usage output.
+func (f Fact) usageName() string {
+	if f.Negatable {
+		return "--[no-]" + StandardizeFactName(f.Name)
+	}
+
+	return f.DressedName()
+}
+
+// usageValue returns the placeholder that follows a
+// flag fact in the usage output, if it takes one.
+func (f Fact) usageValue() string {
+	if !f.Type.takesValue() {
+		return ""
+	}
+
+	name := "VALUE"
+	if f.valueName != "" {
+		name = f.valueName
 	}
 
-	if f.Type != FactTypeBool {
-		valueString := " VALUE"
-		s += valueString
+	if f.Type.slice() {
+		return " " + name + "..."
+	}
+
+	return " " + name
+}
+
+// usageSummary returns a string to be used in the
+// usage line of the argument.PrintUsage function.
+func (f Fact) usageSummary() string {
+	return fmt.Sprintf("[%s%s]", f.usageName(), f.usageValue())
+}
+
+// parseBool accepts a string and returns the boolean
+// that it represents. Accepted values are true,
+// false, 1, 0, yes, and no, ignoring case.
+func parseBool(s string) (bool, error) {
+	switch strings.ToLower(s) {
+	case "true", "1", "yes":
+		return true, nil
+	case "false", "0", "no":
+		return false, nil
 	}
 
-	return s
+	return false, errors.New("invalid boolean value " + s)
 }
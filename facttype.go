@@ -19,6 +19,14 @@ const (
 	FactTypeUInt64
 	FactTypeFloat32
 	FactTypeFloat64
+	FactTypeStringSlice
+	FactTypeIntSlice
+	FactTypeInt64Slice
+	FactTypeUIntSlice
+	FactTypeUInt64Slice
+	FactTypeFloat32Slice
+	FactTypeFloat64Slice
+	FactTypeCounter
 )
 
 // GetFactType accepts an interface and will return
@@ -44,9 +52,76 @@ func GetFactType(v interface{}) (FactType, error) {
 		t = FactTypeFloat32
 	case "*float64":
 		t = FactTypeFloat64
+	case "*[]string":
+		t = FactTypeStringSlice
+	case "*[]int":
+		t = FactTypeIntSlice
+	case "*[]int64":
+		t = FactTypeInt64Slice
+	case "*[]uint":
+		t = FactTypeUIntSlice
+	case "*[]uint64":
+		t = FactTypeUInt64Slice
+	case "*[]float32":
+		t = FactTypeFloat32Slice
+	case "*[]float64":
+		t = FactTypeFloat64Slice
 	default:
 		return FactType(-1), ErrInvalidType
 	}
 
 	return t, nil
 }
+
+// slice returns true if the FactType collects a
+// value for every occurrence of a fact.
+func (t FactType) slice() bool {
+	return t >= FactTypeStringSlice && t <= FactTypeFloat64Slice
+}
+
+// elem returns the FactType of the individual values
+// within a slice FactType. Other FactTypes are
+// returned as they are.
+func (t FactType) elem() FactType {
+	switch t {
+	case FactTypeStringSlice:
+		return FactTypeString
+	case FactTypeIntSlice:
+		return FactTypeInt
+	case FactTypeInt64Slice:
+		return FactTypeInt64
+	case FactTypeUIntSlice:
+		return FactTypeUInt
+	case FactTypeUInt64Slice:
+		return FactTypeUInt64
+	case FactTypeFloat32Slice:
+		return FactTypeFloat32
+	case FactTypeFloat64Slice:
+		return FactTypeFloat64
+	}
+
+	return t
+}
+
+// takesValue returns true if a flag of the FactType
+// requires a value to follow it.
+func (t FactType) takesValue() bool {
+	return t != FactTypeBool && t != FactTypeCounter
+}
+
+// accumulates returns true if the FactType builds
+// its value from every occurrence of a fact.
+func (t FactType) accumulates() bool {
+	return t.slice() || t == FactTypeCounter
+}
+
+// signed returns true if the FactType accepts
+// negative numbers.
+func (t FactType) signed() bool {
+	switch t.elem() {
+	case FactTypeInt, FactTypeInt64, FactTypeFloat32, FactTypeFloat64:
+		return true
+	}
+
+	return false
+}
@@ -50,4 +50,28 @@ func TestGetFactType(t *testing.T) {
 	if ft != FactTypeFloat64 {
 		t.Errorf("GetFactType was incorrect, got: %v, expected: %v", ft, FactTypeFloat64)
 	}
+
+	var ss []string
+	ft, _ = GetFactType(&ss)
+	if ft != FactTypeStringSlice {
+		t.Errorf("GetFactType was incorrect, got: %v, expected: %v", ft, FactTypeStringSlice)
+	}
+
+	var is []int
+	ft, _ = GetFactType(&is)
+	if ft != FactTypeIntSlice {
+		t.Errorf("GetFactType was incorrect, got: %v, expected: %v", ft, FactTypeIntSlice)
+	}
+
+	var fs []float64
+	ft, _ = GetFactType(&fs)
+	if ft != FactTypeFloat64Slice {
+		t.Errorf("GetFactType was incorrect, got: %v, expected: %v", ft, FactTypeFloat64Slice)
+	}
+
+	var bs []bool
+	_, err := GetFactType(&bs)
+	if err != ErrInvalidType {
+		t.Errorf("GetFactType was incorrect, got: %v, expected: %v", err, ErrInvalidType)
+	}
 }
@@ -1,9 +1,14 @@
 package argue
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
 	"os"
 	"reflect"
 	"strings"
+	"text/template"
 )
 
 // Lawyer represents an entity that can parse through
@@ -16,8 +21,21 @@ type Lawyer struct {
 	ShowDesc     bool
 	ShowVersion  bool
 
+	// CollectErrors, if true, makes TakeCustomCase
+	// check every argument before failing, returning
+	// all of the errors that it found joined together.
+	CollectErrors bool
+
 	middleware      func(*Lawyer)
+	ctxMiddleware   func(context.Context, *Lawyer) error
+	handler         func(*Lawyer)
+	ctxHandler      func(context.Context, *Lawyer) error
+	defaultCommand  string
+	commandSuffix   string
+	noHelpCommand   bool
 	defaultArgument Argument
+	usageTemplate   *template.Template
+	console
 }
 
 // NewLawyer returns a new Lawyer with the version
@@ -52,6 +70,43 @@ func (l *Lawyer) AddFact(name string, help string, v interface{}) *Fact {
 	return l.defaultArgument.AddFlagFact(name, help, v)
 }
 
+// SetGroupOrder sets the order that the passed
+// groups of the received Lawyer's flag facts are
+// shown in usage information. Groups that are not
+// passed follow in the order that their first fact
+// was added.
+func (l *Lawyer) SetGroupOrder(groups ...string) {
+	l.defaultArgument.SetGroupOrder(groups...)
+}
+
+// SetOutput sets the writers that the received
+// Lawyer and its sub-arguments print usage and
+// version information to, and error messages to,
+// respectively. A nil writer restores the default,
+// which is the standard output for information and
+// the standard error for errors.
+func (l *Lawyer) SetOutput(stdout io.Writer, stderr io.Writer) {
+	l.setOutput(stdout, stderr)
+}
+
+// SetExitFunc sets the function that the received
+// Lawyer and its sub-arguments call to exit the
+// program in strict mode. By default, os.Exit is
+// used.
+func (l *Lawyer) SetExitFunc(f func(int)) {
+	l.exitFn = f
+}
+
+// SetWidth sets the number of columns that the usage
+// information of the received Lawyer and its
+// sub-arguments is wrapped to. By default, the width
+// is taken from the COLUMNS environment variable or
+// the terminal, and nothing is wrapped if neither is
+// available. A negative width disables wrapping.
+func (l *Lawyer) SetWidth(width int) {
+	l.width = width
+}
+
 // SetMiddleware sets a function that will be called
 // before any SubArgument handlers are called. This
 // is often used to handle the individual facts that
@@ -60,12 +115,73 @@ func (l *Lawyer) SetMiddleware(f func(*Lawyer)) {
 	l.middleware = f
 }
 
+// SetHandler sets a function that will be called
+// when no command is provided, after the Lawyer's
+// own facts have been disputed and its middleware
+// has run.
+func (l *Lawyer) SetHandler(f func(*Lawyer)) {
+	l.handler = f
+}
+
+// SetContextHandler sets a function that will be
+// called in place of the handler set with SetHandler.
+// It receives the context passed to
+// TakeCustomCaseContext, and any error that it
+// returns is passed on to the caller.
+func (l *Lawyer) SetContextHandler(f func(context.Context, *Lawyer) error) {
+	l.ctxHandler = f
+}
+
+// SetContextMiddleware sets a function that will be
+// called after the middleware set with SetMiddleware.
+// It receives the context passed to
+// TakeCustomCaseContext, and returning an error stops
+// the run before any handler is called.
+func (l *Lawyer) SetContextMiddleware(f func(context.Context, *Lawyer) error) {
+	l.ctxMiddleware = f
+}
+
+// SetDefaultCommand sets the name of the
+// sub-argument to run when no command is provided.
+// The sub-argument must already exist, and takes
+// precedence over the handler set with SetHandler.
+func (l *Lawyer) SetDefaultCommand(n string) {
+	if n == "" {
+		l.defaultCommand = ""
+		return
+	}
+
+	sa, ok := l.commandSpecified(n)
+	if !ok {
+		panic("argue: the default command is not a sub-argument")
+	}
+
+	l.defaultCommand = sa.Name
+}
+
+// SetHelpCommand accepts a bool and sets whether
+// the received Lawyer provides a "help" command,
+// which prints the usage information of the Lawyer
+// or of the command named after it. The command is
+// provided by default unless a sub-argument of the
+// same name exists.
+func (l *Lawyer) SetHelpCommand(enabled bool) {
+	l.noHelpCommand = !enabled
+}
+
+// helpCommand returns true if the received Lawyer
+// provides a "help" command.
+func (l Lawyer) helpCommand() bool {
+	return !l.noHelpCommand && !l.NameExists("help")
+}
+
 // AddArgumentFromStruct offers a new argument to the
-// Lawyer with the passed parameters: name, help, and
-// the argument to add.
-func (l *Lawyer) AddArgumentFromStruct(n string, h string, str interface{}) *SubArgument {
+// Lawyer with the passed parameters: name, help, the
+// struct to build the argument from, and any aliases
+// of the name.
+func (l *Lawyer) AddArgumentFromStruct(n string, h string, str interface{}, aliases ...string) *SubArgument {
 	arg := NewEmptyArgumentFromStruct(str)
-	return l.AddArgument(n, h, arg)
+	return l.AddArgument(n, h, arg).AddAlias(aliases...)
 }
 
 // AddArgument offers a new argument to the Lawyer
@@ -85,14 +201,50 @@ func (l *Lawyer) AddArgument(n string, h string, arg Argument) *SubArgument {
 	sarg.Help = h
 	sarg.Argument = arg
 	sarg.handler = nil
+	sarg.owner = l
 
 	l.SubArguments = append(l.SubArguments, &sarg)
 	return &sarg
 }
 
+// AddLawyer offers a nested Lawyer to the received
+// Lawyer with the passed parameters: name, help, and
+// the Lawyer to add. The nested Lawyer takes the
+// case of the arguments that follow its name, which
+// allows for multiple levels of sub-commands.
+func (l *Lawyer) AddLawyer(n string, h string, law Lawyer) *SubArgument {
+	if l.NameExists(n) {
+		panic("argue: this name already exists as a sub-argument")
+	}
+
+	law.commandSuffix = strings.ToLower(n)
+
+	var sarg SubArgument
+	sarg.Name = n
+	sarg.Help = h
+	sarg.Lawyer = &law
+	sarg.owner = l
+
+	l.SubArguments = append(l.SubArguments, &sarg)
+	return &sarg
+}
+
+// commandPath returns the command suffix of the
+// sub-argument with the passed name, which follows
+// the suffix of the received Lawyer.
+func (l Lawyer) commandPath(n string) string {
+	n = strings.ToLower(n)
+	if l.commandSuffix == "" {
+		return n
+	}
+
+	return l.commandSuffix + " " + n
+}
+
 // NameExists accepts a proposed name for a
 // sub-argument and checks if it already exists
-// within the received Laywer.
+// within the received Laywer, either as the name or
+// an alias of a sub-argument.
 func (l Lawyer) NameExists(n string) bool {
 	// Format name
 	n = strings.ToUpper(n)
@@ -100,110 +252,407 @@ func (l Lawyer) NameExists(n string) bool {
 
 	// Check if name exists
 	for _, sa := range l.SubArguments {
-		n2 := strings.ToUpper(sa.Name)
-		if n == n2 {
-			// Name found
-			return true
+		for _, n2 := range sa.names() {
+			if n == strings.ToUpper(n2) {
+				// Name found
+				return true
+			}
 		}
 	}
 
 	return false
 }
 
+// fail prints the passed errors and exits the
+// program if strict is true. The errors are returned
+// as one so that they may be passed on to the
+// caller.
+func (l Lawyer) fail(strict bool, errs []*ParseError) error {
+	if strict {
+		l.printErrors(errorMessages(errs))
+	}
+
+	return joinParseErrors(errs)
+}
+
+// collectable returns true if the passed error from
+// disputing an argument should be collected with the
+// others. Requests for help or version information
+// exit the program if strict is true.
+func (l Lawyer) collectable(err error, strict bool) bool {
+	if !l.CollectErrors {
+		return false
+	}
+
+	if errors.Is(err, ErrHelpRequested) || errors.Is(err, ErrVersionRequested) {
+		if strict {
+			l.exit(0)
+		}
+
+		return false
+	}
+
+	return len(ParseErrors(err)) > 0
+}
+
 // TakeCase implements TakeCustomCase with os.Args.
 func (l Lawyer) TakeCase(mw bool) error {
 	return l.TakeCustomCase(os.Args[1:], mw)
 }
 
+// TakeCaseContext implements TakeCustomCaseContext
+// with os.Args.
+func (l Lawyer) TakeCaseContext(ctx context.Context, mw bool) error {
+	return l.TakeCustomCaseContext(ctx, os.Args[1:], mw)
+}
+
 func (l Lawyer) commandSpecified(cmd string) (*SubArgument, bool) {
 	cmd = strings.ToUpper(cmd)
 	for _, sa := range l.SubArguments {
-		name := strings.ToUpper(sa.Name)
-		if cmd == name {
-			return sa, true
+		for _, name := range sa.names() {
+			if cmd == strings.ToUpper(name) {
+				return sa, true
+			}
 		}
 	}
 
 	return &SubArgument{}, false
 }
 
+// commandSuggestions returns the names of the
+// sub-arguments that the passed unknown command may
+// have been meant to be.
+func (l Lawyer) commandSuggestions(cmd string) []string {
+	var names []string
+	for _, sa := range l.SubArguments {
+		if sa.Hidden {
+			continue
+		}
+
+		for _, name := range sa.names() {
+			names = append(names, strings.ToLower(name))
+		}
+	}
+
+	if l.helpCommand() {
+		names = append(names, "help")
+	}
+
+	return suggest(cmd, names)
+}
+
+// printHelp prints the usage information of the
+// command that the passed names lead to, or of the
+// received Lawyer if there are none. The offset is
+// the position of the names within the arguments
+// passed to the outermost Lawyer.
+func (l Lawyer) printHelp(names []string, offset int) *ParseError {
+	for i, name := range names {
+		if name == "--" {
+			continue
+		}
+
+		sa, ok := l.commandSpecified(name)
+		if !ok {
+			suggestions := l.commandSuggestions(name)
+			perr := newParseError(ErrUnknownCommand, "unknown command "+name+" provided"+suggestionMessage(suggestions))
+			perr.Suggestions = suggestions
+			perr.at(names, i).Index += offset
+			return perr
+		}
+
+		if sa.Lawyer == nil {
+			arg := sa.Argument
+			arg.commandSuffix = l.commandPath(sa.Name)
+			arg.inherit(l.console)
+			arg.PrintUsage()
+			return nil
+		}
+
+		nested := *sa.Lawyer
+		nested.commandSuffix = l.commandPath(sa.Name)
+		nested.inherit(l.console)
+		l = nested
+	}
+
+	l.PrintUsage()
+	return nil
+}
+
 // TakeCustomCase accepts some arguments and will
 // parse through them according to the sub-commands
 // that the Lawyer has. The arguments passed to this
-// function should not include the binary name.
+// function should not include the binary name. If
+// help or version information is requested, it is
+// printed and the program exits when mw is true,
+// otherwise ErrHelpRequested or ErrVersionRequested
+// is returned. Nested Lawyers take the case of the
+// arguments that follow their name, and the
+// middleware of each Lawyer runs from the outermost
+// to the innermost before the handler.
 func (l Lawyer) TakeCustomCase(arguments []string, mw bool) error {
-	commandArgs := arguments
-
-	// Extract all flags up to a command
-	var flags []string
-	for _, arg := range arguments {
-		if flagReg.MatchString(arg) {
-			flags = append(flags, arg)
-
-			// Shave off this flag
-			commandArgs = commandArgs[1:]
-		} else {
-			_, ok := l.commandSpecified(arg)
-			if ok {
-				break
-			} else {
-				flags = append(flags, arg)
-
-				// Shave off this flag
-				commandArgs = commandArgs[1:]
-			}
+	return l.TakeCustomCaseContext(context.Background(), arguments, mw)
+}
+
+// TakeCustomCaseContext implements TakeCustomCase,
+// passing the context to context-aware middleware
+// and handlers. If middleware or a handler returns
+// an error, or the context is done before they run,
+// the error is returned. When mw is true, the error
+// is printed and the program exits with an error
+// code of 1, or the code returned by the error's
+// ExitCode method if it has one.
+func (l Lawyer) TakeCustomCaseContext(ctx context.Context, arguments []string, mw bool) error {
+	return l.takeCase(ctx, arguments, mw, 0, nil, nil)
+}
+
+// takeCase implements TakeCustomCaseContext for the
+// received Lawyer, which may be nested. The offset
+// is the position of the arguments within those
+// passed to the outermost Lawyer, errs holds the
+// errors collected by outer Lawyers, and outer holds
+// their middleware.
+func (l Lawyer) takeCase(ctx context.Context, arguments []string, mw bool, offset int, errs []*ParseError, outer []func(context.Context) error) error {
+	// Extract all flags up to a command, keeping any
+	// values that the flags consume. The first value
+	// that is not a flag is the command
+	i := 0
+	for i < len(arguments) && arguments[i] != "--" && l.defaultArgument.isFlag(arguments[i]) {
+		_, n := l.defaultArgument.splitFlag(arguments[i:])
+		i += n
+	}
+
+	// The terminator also ends the flags
+	terminated := i < len(arguments) && arguments[i] == "--"
+
+	flags := arguments[:i]
+	commandArgs := arguments[i:]
+	commandIndex := i
+
+	// When terminated, the command must follow the
+	// terminator, and every argument after the command
+	// is positional
+	if terminated {
+		commandArgs = arguments[i+1:]
+		commandIndex = i + 1
+		if len(commandArgs) > 0 {
+			commandArgs = append([]string{commandArgs[0], "--"}, commandArgs[1:]...)
 		}
 	}
 
 	// Check if --help or --version are present
-	for _, f := range flags {
+	_, fm := l.defaultArgument.SplitArguments(flags)
+	for f := range fm {
+		if f == "-h" || f == "--help" {
+			l.PrintUsage()
+			if mw {
+				l.exit(0)
+			}
+
+			return ErrHelpRequested
+		}
+	}
+
+	for f := range fm {
 		if l.ShowVersion && (f == "-v" || f == "--version") {
 			l.PrintVersion()
-			os.Exit(0)
-		}
+			if mw {
+				l.exit(0)
+			}
 
-		if f == "-h" || f == "--help" {
-			l.PrintUsage()
-			os.Exit(0)
+			return ErrVersionRequested
 		}
 	}
 
-	// Check if no command was provided
+	// Fall back on the default command or the handler
+	// if no command was provided
 	if len(commandArgs) == 0 {
+		if l.defaultCommand != "" {
+			commandArgs = []string{l.defaultCommand}
+		} else if l.handler == nil && l.ctxHandler == nil {
+			perr := newParseError(ErrNoCommand, "no valid command was provided")
+			return l.fail(mw, append(errs, perr))
+		}
+	}
+
+	// Print the usage of the command named after the
+	// help command
+	if len(commandArgs) > 0 && l.helpCommand() && strings.EqualFold(commandArgs[0], "help") {
+		if err := l.printHelp(commandArgs[1:], offset+commandIndex+1); err != nil {
+			return l.fail(mw, append(errs, err))
+		}
+
 		if mw {
-			l.PrintError("no valid command was provided")
+			l.exit(0)
 		}
 
-		return ErrNoCommand
+		return ErrHelpRequested
 	}
 
-	// Try to dispute the default flags
-	err := l.defaultArgument.DisputeCustom(flags, mw)
+	// Try to dispute the default flags. When collecting
+	// errors, they are printed together at the end
+	l.defaultArgument.inherit(l.console)
+	l.defaultArgument.CollectErrors = l.CollectErrors
+	err := l.defaultArgument.DisputeCustom(flags, mw && !l.CollectErrors)
 	if err != nil {
-		return err
+		shiftIndex(err, offset)
+		if !l.collectable(err, mw) {
+			return err
+		}
+
+		errs = append(errs, ParseErrors(err)...)
+	}
+
+	// Queue middleware to run once every argument has
+	// been disputed
+	if l.middleware != nil {
+		outer = append(outer, func(context.Context) error {
+			l.middleware(&l)
+			return nil
+		})
+	}
+
+	if l.ctxMiddleware != nil {
+		outer = append(outer, func(ctx context.Context) error {
+			return l.ctxMiddleware(ctx, &l)
+		})
+	}
+
+	// Run the handler of the received Lawyer if there
+	// is no command
+	if len(commandArgs) == 0 {
+		if len(errs) > 0 {
+			return l.fail(mw, errs)
+		}
+
+		return l.run(ctx, mw, append(outer, func(ctx context.Context) error {
+			if l.ctxHandler != nil {
+				return l.ctxHandler(ctx, &l)
+			}
+
+			l.handler(&l)
+			return nil
+		}))
 	}
 
 	// Try to dispute appropriate command
-	subArgument, _ := l.commandSpecified(commandArgs[0])
-	err = subArgument.Argument.DisputeCustom(commandArgs[1:], mw)
+	subArgument, ok := l.commandSpecified(commandArgs[0])
+	if !ok {
+		suggestions := l.commandSuggestions(commandArgs[0])
+		perr := newParseError(ErrUnknownCommand, "unknown command "+commandArgs[0]+" provided"+suggestionMessage(suggestions))
+		perr.Suggestions = suggestions
+		shiftIndex(perr.at(arguments, commandIndex), offset)
+		return l.fail(mw, append(errs, perr))
+	}
+
+	if subArgument.Deprecated != "" {
+		l.warn(fmt.Sprintf("command %s is deprecated, %s", strings.ToLower(subArgument.Name), subArgument.Deprecated))
+	}
+
+	// Hand the rest of the arguments to a copy of a
+	// nested Lawyer, which shares the configuration
+	// file, if there is one, and reads the section
+	// named after its path. Copies keep the settings
+	// of this run from being stored in the
+	// sub-argument
+	if subArgument.Lawyer != nil {
+		nested := *subArgument.Lawyer
+		nested.commandSuffix = l.commandPath(subArgument.Name)
+		if path := l.configFile(); path != "" {
+			nested.defaultArgument.configFile = path
+			nested.defaultArgument.configSection = sectionPath(l.defaultArgument.configSection, subArgument.Name)
+		}
+		nested.inherit(l.console)
+		if l.CollectErrors {
+			nested.CollectErrors = true
+		}
+
+		return nested.takeCase(ctx, commandArgs[1:], mw, offset+commandIndex+1, errs, outer)
+	}
+
+	// Share the configuration file, if there is one,
+	// with a copy of the command, which reads the
+	// section named after its path
+	arg := subArgument.Argument
+	arg.commandSuffix = l.commandPath(subArgument.Name)
+	if path := l.configFile(); path != "" {
+		arg.configFile = path
+		arg.configSection = sectionPath(l.defaultArgument.configSection, subArgument.Name)
+	}
+	arg.inherit(l.console)
+	if l.CollectErrors {
+		arg.CollectErrors = true
+	}
+
+	// Positions within the command's arguments are
+	// shifted to match the arguments that were passed
+	err = arg.DisputeCustom(commandArgs[1:], mw && !l.CollectErrors)
 	if err != nil {
-		return err
+		shiftIndex(err, offset+commandIndex+1)
+		if !l.collectable(err, mw) {
+			return err
+		}
+
+		errs = append(errs, ParseErrors(err)...)
 	}
 
-	// Run middleware if it is specified
-	if l.middleware != nil {
-		l.middleware(&l)
+	if len(errs) > 0 {
+		return l.fail(mw, errs)
 	}
 
-	// Run the handler if it is specified
-	if subArgument.handler != nil {
+	// Run middleware from the outermost Lawyer inwards,
+	// followed by the handler if it is specified
+	return l.run(ctx, mw, append(outer, func(ctx context.Context) error {
+		var v interface{}
 		if subArgument.Argument.baseStruct != nil {
-			val := reflect.ValueOf(subArgument.Argument.baseStruct)
-			subArgument.handler(&l, reflect.Indirect(val).Interface())
-		} else {
-			subArgument.handler(&l, nil)
+			v = reflect.Indirect(reflect.ValueOf(subArgument.Argument.baseStruct)).Interface()
+		}
+
+		if subArgument.ctxHandler != nil {
+			return subArgument.ctxHandler(ctx, &l, v)
+		}
+
+		if subArgument.handler != nil {
+			subArgument.handler(&l, v)
+		}
+
+		return nil
+	}))
+}
+
+// run calls each of the passed functions in order,
+// stopping at the first error or once the passed
+// context is done. The error is printed and the
+// program exits if strict is true.
+func (l Lawyer) run(ctx context.Context, strict bool, fs []func(context.Context) error) error {
+	for _, f := range fs {
+		err := ctx.Err()
+		if err == nil {
+			err = f(ctx)
+		}
+
+		if err != nil {
+			if strict {
+				fmt.Fprintf(l.errorWriter(), "Error: %v\n", err)
+				l.exit(exitCode(err))
+			}
+
+			return err
 		}
 	}
 
 	return nil
 }
+
+// exitCode returns the code that the program should
+// exit with because of the passed error, which is
+// the code returned by its ExitCode method if it has
+// one, or 1 otherwise.
+func exitCode(err error) int {
+	var coder interface{ ExitCode() int }
+	if errors.As(err, &coder) {
+		return coder.ExitCode()
+	}
+
+	return 1
+}
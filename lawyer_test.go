@@ -0,0 +1,427 @@
+package argue
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestTakeCustomCaseErrors(t *testing.T) {
+	type command struct {
+		Count int
+	}
+
+	var c command
+	var debug bool
+	law := NewEmptyLawyer()
+	law.AddFact("debug", "enable debugging", &debug)
+	law.AddArgumentFromStruct("run", "run something", &c)
+
+	err := law.TakeCustomCase([]string{"--debug"}, false)
+	if !errors.Is(err, ErrNoCommand) {
+		t.Errorf("TakeCustomCase was incorrect, expected: ErrNoCommand, got %v", err)
+	}
+
+	var perr *ParseError
+	err = law.TakeCustomCase([]string{"--debug", "run", "--count", "abc"}, false)
+	if !errors.As(err, &perr) || !errors.Is(err, ErrWrongType) {
+		t.Fatalf("TakeCustomCase was incorrect, expected: ErrWrongType, got %v", err)
+	}
+
+	if perr.Token != "abc" || perr.Index != 3 {
+		t.Errorf("TakeCustomCase was incorrect, got: %q at %d, expected: \"abc\" at 3", perr.Token, perr.Index)
+	}
+
+	err = law.TakeCustomCase([]string{"--", "walk"}, false)
+	if !errors.As(err, &perr) || !errors.Is(err, ErrUnknownCommand) || perr.Index != 1 {
+		t.Errorf("TakeCustomCase was incorrect, expected: ErrUnknownCommand at 1, got %v", err)
+	}
+}
+
+func TestTakeCustomCaseCollectErrors(t *testing.T) {
+	type command struct {
+		Count int
+		Name  string `options:"required"`
+	}
+
+	var c command
+	var level int
+	law := NewEmptyLawyer()
+	law.AddFact("level", "set the level", &level)
+	law.AddArgumentFromStruct("run", "run something", &c)
+	law.CollectErrors = true
+
+	err := law.TakeCustomCase([]string{"--level", "high", "run", "--count", "abc"}, false)
+	perrs := ParseErrors(err)
+	if len(perrs) != 3 {
+		t.Fatalf("TakeCustomCase was incorrect, got: %d errors, expected: %d errors", len(perrs), 3)
+	}
+
+	if perrs[0].Index != 1 || !errors.Is(perrs[1], ErrMissingFlag) || perrs[2].Index != 4 {
+		t.Errorf("TakeCustomCase was incorrect, got: %v", err)
+	}
+
+	// Collecting must not carry over to later runs
+	law.CollectErrors = false
+	err = law.TakeCustomCase([]string{"run", "--count", "abc"}, false)
+	if perrs := ParseErrors(err); len(perrs) != 1 {
+		t.Errorf("TakeCustomCase was incorrect, got: %d errors, expected: %d errors", len(perrs), 1)
+	}
+}
+
+func TestHiddenDeprecatedCommands(t *testing.T) {
+	type command struct {
+		Count int
+	}
+
+	var c command
+	var stderr bytes.Buffer
+	law := NewEmptyLawyer()
+	law.SetOutput(nil, &stderr)
+	law.AddArgumentFromStruct("internal", "internal tooling", &c).SetHidden(true)
+	law.AddArgumentFromStruct("start", "start the service", &c).SetDeprecated("use run instead")
+	law.AddArgumentFromStruct("run", "run the service", &c)
+
+	usage := law.UsageString()
+	if strings.Contains(usage, "internal") {
+		t.Errorf("UsageString was incorrect, got:\n%s\nexpected no internal command", usage)
+	}
+
+	if err := law.TakeCustomCase([]string{"internal"}, false); err != nil {
+		t.Errorf("TakeCustomCase was incorrect, got: %v, expected: nil", err)
+	}
+
+	if err := law.TakeCustomCase([]string{"start"}, false); err != nil {
+		t.Errorf("TakeCustomCase was incorrect, got: %v, expected: nil", err)
+	}
+
+	expected := "Warning: command start is deprecated, use run instead\n"
+	if stderr.String() != expected {
+		t.Errorf("Warning was incorrect, got: %q, expected: %q", stderr.String(), expected)
+	}
+
+	var perr *ParseError
+	err := law.TakeCustomCase([]string{"intern"}, false)
+	if !errors.As(err, &perr) || len(perr.Suggestions) != 0 {
+		t.Errorf("TakeCustomCase was incorrect, got: %v, expected no suggestions", err)
+	}
+}
+
+func TestNestedLawyers(t *testing.T) {
+	type add struct {
+		Name string `options:"required"`
+	}
+
+	var order []string
+	var a add
+	node := NewEmptyLawyer()
+	node.SetMiddleware(func(*Lawyer) { order = append(order, "node") })
+	node.AddArgumentFromStruct("add", "add a node", &a).SetHandler(func(_ *Lawyer, v interface{}) {
+		order = append(order, "add "+v.(add).Name)
+	})
+
+	cluster := NewEmptyLawyer()
+	cluster.SetMiddleware(func(*Lawyer) { order = append(order, "cluster") })
+	cluster.AddLawyer("node", "manage nodes", node)
+
+	var verbose bool
+	law := NewEmptyLawyer()
+	law.AddFact("verbose", "be verbose", &verbose)
+	law.SetMiddleware(func(*Lawyer) { order = append(order, "root") })
+	law.AddLawyer("cluster", "manage clusters", cluster)
+
+	err := law.TakeCustomCase([]string{"--verbose", "cluster", "node", "add", "--name", "n1"}, false)
+	if err != nil {
+		t.Fatalf("TakeCustomCase was incorrect, got: %v, expected: nil", err)
+	}
+
+	expected := "root cluster node add n1"
+	if got := strings.Join(order, " "); got != expected {
+		t.Errorf("TakeCustomCase was incorrect, got: %q, expected: %q", got, expected)
+	}
+
+	var stdout bytes.Buffer
+	law.SetOutput(&stdout, nil)
+	err = law.TakeCustomCase([]string{"cluster", "node", "--help"}, false)
+	if !errors.Is(err, ErrHelpRequested) || !strings.Contains(stdout.String(), " cluster node COMMAND") {
+		t.Errorf("TakeCustomCase was incorrect, got: %v\n%s\nexpected the usage of cluster node", err, stdout.String())
+	}
+
+	var perr *ParseError
+	err = law.TakeCustomCase([]string{"cluster", "node", "ad"}, false)
+	if !errors.As(err, &perr) || !errors.Is(err, ErrUnknownCommand) || perr.Index != 2 {
+		t.Errorf("TakeCustomCase was incorrect, got: %v, expected: ErrUnknownCommand at 2", err)
+	}
+
+	err = law.TakeCustomCase([]string{"cluster", "node", "add", "--name"}, false)
+	if !errors.As(err, &perr) || perr.Index != 3 {
+		t.Errorf("TakeCustomCase was incorrect, got: %v, expected: an error at 3", err)
+	}
+}
+
+func TestCommandAliases(t *testing.T) {
+	type command struct {
+		Force bool
+	}
+
+	var c command
+	var called string
+	law := NewEmptyLawyer()
+	law.AddArgumentFromStruct("remove", "remove an item", &c, "rm").SetHandler(func(*Lawyer, interface{}) {
+		called = "remove"
+	})
+	law.AddArgumentFromStruct("list", "list the items", &c).AddAlias("ls").SetHandler(func(*Lawyer, interface{}) {
+		called = "list"
+	})
+
+	if err := law.TakeCustomCase([]string{"RM", "--force"}, false); err != nil || called != "remove" {
+		t.Errorf("TakeCustomCase was incorrect, got: %q %v, expected: \"remove\" <nil>", called, err)
+	}
+
+	if err := law.TakeCustomCase([]string{"ls"}, false); err != nil || called != "list" {
+		t.Errorf("TakeCustomCase was incorrect, got: %q %v, expected: \"list\" <nil>", called, err)
+	}
+
+	if !law.NameExists("rm") {
+		t.Errorf("NameExists was incorrect, got: false, expected: true")
+	}
+
+	law.SetWidth(-1)
+	usage := law.UsageString()
+	if !strings.Contains(usage, "  remove, rm    remove an item\n") || !strings.Contains(usage, "  list, ls      list the items\n") {
+		t.Errorf("UsageString was incorrect, got:\n%s\nexpected aliases next to the commands", usage)
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("AddArgumentFromStruct did not panic on an existing alias")
+			}
+		}()
+		law.AddArgumentFromStruct("rm", "remove quickly", &c)
+	}()
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("AddArgumentFromStruct did not panic on an alias of its own name")
+			}
+		}()
+		law.AddArgumentFromStruct("show", "show an item", &c, "show")
+	}()
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("AddAlias did not panic on an existing name")
+		}
+
+		if usage := law.UsageString(); strings.Count(usage, "remove") != 2 {
+			t.Errorf("UsageString was incorrect, got:\n%s\nexpected remove to be listed once", usage)
+		}
+	}()
+	law.SubArguments[1].AddAlias("remove")
+}
+
+func TestHelpCommand(t *testing.T) {
+	type add struct {
+		Name string
+	}
+
+	var a add
+	node := NewEmptyLawyer()
+	node.AddArgumentFromStruct("add", "add a node", &a)
+
+	var stdout bytes.Buffer
+	law := NewEmptyLawyer()
+	law.SetOutput(&stdout, nil)
+	law.AddLawyer("node", "manage nodes", node)
+
+	if usage := law.UsageString(); !strings.Contains(usage, "  help    display help for a command\n") {
+		t.Errorf("UsageString was incorrect, got:\n%s\nexpected the help command", usage)
+	}
+
+	err := law.TakeCustomCase([]string{"help"}, false)
+	if !errors.Is(err, ErrHelpRequested) || stdout.String() != law.UsageString() {
+		t.Errorf("TakeCustomCase was incorrect, got: %v\n%s\nexpected the usage of the lawyer", err, stdout.String())
+	}
+
+	stdout.Reset()
+	err = law.TakeCustomCase([]string{"help", "node", "add"}, false)
+	if !errors.Is(err, ErrHelpRequested) || !strings.Contains(stdout.String(), " node add [--name VALUE]\n") {
+		t.Errorf("TakeCustomCase was incorrect, got: %v\n%s\nexpected the usage of node add", err, stdout.String())
+	}
+
+	var perr *ParseError
+	err = law.TakeCustomCase([]string{"help", "node", "ad"}, false)
+	if !errors.As(err, &perr) || !errors.Is(err, ErrUnknownCommand) || perr.Index != 2 {
+		t.Errorf("TakeCustomCase was incorrect, got: %v, expected: ErrUnknownCommand at 2", err)
+	}
+
+	law.SetHelpCommand(false)
+	err = law.TakeCustomCase([]string{"help"}, false)
+	if !errors.Is(err, ErrUnknownCommand) {
+		t.Errorf("TakeCustomCase was incorrect, got: %v, expected: ErrUnknownCommand", err)
+	}
+}
+
+func TestDefaultCommand(t *testing.T) {
+	type command struct {
+		Short bool
+	}
+
+	var c command
+	var called string
+	var verbose bool
+	law := NewEmptyLawyer()
+	law.AddFact("verbose", "be verbose", &verbose)
+	law.AddArgumentFromStruct("status", "show the status", &c, "st").SetHandler(func(*Lawyer, interface{}) {
+		called = "status"
+	})
+
+	err := law.TakeCustomCase([]string{"--verbose"}, false)
+	if !errors.Is(err, ErrNoCommand) {
+		t.Errorf("TakeCustomCase was incorrect, got: %v, expected: ErrNoCommand", err)
+	}
+
+	law.SetHandler(func(l *Lawyer) {
+		called = "root"
+	})
+	if err := law.TakeCustomCase([]string{"--verbose"}, false); err != nil || called != "root" || !verbose {
+		t.Errorf("TakeCustomCase was incorrect, got: %q %v, expected: \"root\" <nil>", called, err)
+	}
+
+	law.SetDefaultCommand("st")
+	if err := law.TakeCustomCase(nil, false); err != nil || called != "status" {
+		t.Errorf("TakeCustomCase was incorrect, got: %q %v, expected: \"status\" <nil>", called, err)
+	}
+
+	law.SetWidth(-1)
+	usage := law.UsageString()
+	if !strings.Contains(usage, " [COMMAND]\n") || !strings.Contains(usage, "show the status (default)\n") {
+		t.Errorf("UsageString was incorrect, got:\n%s\nexpected an optional default command", usage)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("SetDefaultCommand did not panic on an unknown command")
+		}
+	}()
+	law.SetDefaultCommand("stop")
+}
+
+type exitError struct {
+	code int
+}
+
+func (e exitError) Error() string {
+	return fmt.Sprintf("failed with %d", e.code)
+}
+
+func (e exitError) ExitCode() int {
+	return e.code
+}
+
+func TestContextHandlers(t *testing.T) {
+	type command struct {
+		Fail bool
+	}
+
+	var c command
+	var called bool
+	law := NewEmptyLawyer()
+	law.AddArgumentFromStruct("run", "run something", &c).SetContextHandler(func(ctx context.Context, l *Lawyer, v interface{}) error {
+		called = true
+		if v.(command).Fail {
+			return exitError{3}
+		}
+
+		return nil
+	})
+
+	ctx := context.Background()
+	if err := law.TakeCustomCaseContext(ctx, []string{"run"}, false); err != nil || !called {
+		t.Errorf("TakeCustomCaseContext was incorrect, got: %v %v, expected: <nil> true", err, called)
+	}
+
+	err := law.TakeCustomCaseContext(ctx, []string{"run", "--fail"}, false)
+	if !errors.Is(err, exitError{3}) {
+		t.Errorf("TakeCustomCaseContext was incorrect, got: %v, expected: %v", err, exitError{3})
+	}
+
+	var code int
+	var stderr bytes.Buffer
+	law.SetOutput(nil, &stderr)
+	law.SetExitFunc(func(c int) { code = c })
+	law.TakeCustomCaseContext(ctx, []string{"run", "--fail"}, true)
+	if code != 3 || stderr.String() != "Error: failed with 3\n" {
+		t.Errorf("TakeCustomCaseContext was incorrect, got: %d %q, expected: 3 \"Error: failed with 3\\n\"", code, stderr.String())
+	}
+
+	abort := errors.New("aborted")
+	called = false
+	law.SetContextMiddleware(func(context.Context, *Lawyer) error { return abort })
+	err = law.TakeCustomCaseContext(ctx, []string{"run"}, false)
+	if err != abort || called {
+		t.Errorf("TakeCustomCaseContext was incorrect, got: %v %v, expected: aborted false", err, called)
+	}
+
+	law.SetContextMiddleware(nil)
+	law.SetContextHandler(func(context.Context, *Lawyer) error { return nil })
+	if err := law.TakeCustomCaseContext(ctx, nil, false); err != nil {
+		t.Errorf("TakeCustomCaseContext was incorrect, got: %v, expected: nil", err)
+	}
+
+	law.SetWidth(-1)
+	if usage := law.UsageString(); !strings.Contains(usage, " [COMMAND]\n") {
+		t.Errorf("UsageString was incorrect, got:\n%s\nexpected an optional command", usage)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	err = law.TakeCustomCaseContext(cancelled, []string{"run"}, false)
+	if !errors.Is(err, context.Canceled) || called {
+		t.Errorf("TakeCustomCaseContext was incorrect, got: %v %v, expected: context.Canceled false", err, called)
+	}
+}
+
+func TestLawyerOutputBetweenRuns(t *testing.T) {
+	type command struct {
+		Count int
+	}
+
+	type add struct {
+		Count int
+	}
+
+	var c command
+	var a add
+	node := NewEmptyLawyer()
+	node.AddArgumentFromStruct("add", "add a node", &a)
+
+	law := NewEmptyLawyer()
+	law.AddArgumentFromStruct("run", "run something", &c)
+	law.AddLawyer("node", "manage nodes", node)
+
+	for _, args := range [][]string{{"run", "--count", "abc"}, {"node", "add", "--count", "abc"}} {
+		var first, second bytes.Buffer
+		var firstCode, secondCode int
+		law.SetOutput(&first, &first)
+		law.SetExitFunc(func(c int) { firstCode = c })
+		law.TakeCustomCase(args, true)
+
+		law.SetOutput(&second, &second)
+		law.SetExitFunc(func(c int) { secondCode = c })
+		law.TakeCustomCase(args, true)
+
+		if first.Len() == 0 || second.String() != first.String() {
+			t.Errorf("TakeCustomCase was incorrect for %v, got: %q and %q, expected the same error in both", args, first.String(), second.String())
+		}
+
+		if firstCode != 1 || secondCode != 1 {
+			t.Errorf("TakeCustomCase was incorrect for %v, got: %d and %d, expected: 1 and 1", args, firstCode, secondCode)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package argue
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"strings"
 )
@@ -9,91 +10,144 @@ import (
 // PrintError accepts a message and will print it
 // as an error message along with the received
 // Lawyer's usage line. This will exit the program
-// with an error code of 1.
+// with an error code of 1 using the exit function
+// of the Lawyer.
 func (l Lawyer) PrintError(msg string) {
-	printError(msg)
+	l.printErrors([]string{msg})
+}
+
+// printErrors prints each of the passed messages as
+// an error message, followed by the received
+// Lawyer's usage line, and exits the program with an
+// error code of 1.
+func (l Lawyer) printErrors(msgs []string) {
+	out := l.errorWriter()
+	for _, msg := range msgs {
+		fmt.Fprintf(out, "Error: %v\n", msg)
+	}
+	fmt.Fprintf(out, "Run \"%v", os.Args[0])
+	if l.commandSuffix != "" {
+		fmt.Fprint(out, " "+l.commandSuffix)
+	}
+	fmt.Fprint(out, " --help\" to see usage information\n")
+	l.exit(1)
 }
 
 // PrintUsage writes the usage information of the
-// received Lawyer to the standard output.
+// received Lawyer to its output writer, which is the
+// standard output by default.
 func (l Lawyer) PrintUsage() {
+	l.WriteUsage(l.outputWriter())
+}
+
+// WriteUsage writes the usage information of the
+// received Lawyer to the passed writer, rendered
+// with its usage template.
+func (l Lawyer) WriteUsage(out io.Writer) {
+	tmpl := l.usageTemplate
+	if tmpl == nil {
+		tmpl = defaultLawyerUsageTemplate
+	}
+
+	executeUsageTemplate(out, tmpl, l.usageData(l.outputWidth(out)))
+}
+
+// SetUsageTemplate sets the text/template used to
+// render the usage information of the received
+// Lawyer. The template is executed with a UsageData
+// value. This will panic if the template can not be
+// parsed.
+func (l *Lawyer) SetUsageTemplate(text string) {
+	l.usageTemplate = parseUsageTemplate(text)
+}
+
+// usageData returns the usage information of the
+// received Lawyer, wrapped to the passed width.
+func (l Lawyer) usageData(width int) UsageData {
 	spacing := 4
 
 	// Define help and version flags
 	var dummy bool
 	helpFact := NewFact("display this help and exit", "help", byte("h"[0]), false, false, &dummy)
 	versionFact := NewFact("display version and exit", "version", byte("v"[0]), false, false, &dummy)
-	factBank := append(l.defaultArgument.FlagFacts, &helpFact)
+	defaultFacts := []*Fact{&helpFact}
 	if l.ShowVersion {
-		factBank = append(factBank, &versionFact)
+		defaultFacts = append(defaultFacts, &versionFact)
 	}
 
-	// Calculate the largest sub-command name for spacing
-	// purposes
-	cmdWidth := 0
+	noEnv := func(*Fact) string { return "" }
+	data := UsageData{
+		Name:        binaryName(),
+		Binary:      os.Args[0],
+		Command:     l.commandSuffix,
+		Version:     l.Version,
+		ShowVersion: l.ShowVersion,
+		Description: l.Description,
+		ShowDesc:    l.ShowDesc,
+		Width:       width,
+	}
+
+	ungrouped, groups := l.defaultArgument.flagGroups()
+	data.Flags = append(factEntries(ungrouped, l.defaultArgument.envName), factEntries(defaultFacts, noEnv)...)
+	data.Groups = groupEntries(groups, l.defaultArgument.envName)
+
 	for _, sa := range l.SubArguments {
-		length := len(sa.Name) + 2
-		if length > cmdWidth {
-			cmdWidth = length
+		if sa.Hidden {
+			continue
 		}
-	}
 
-	// Calculate the largest flag header for spacing
-	// purposes
-	flagWidth := 0 // Start with --help width
-	for _, f := range factBank {
-		length := len(f.usageHeader()) + 2
-		if length > flagWidth {
-			flagWidth = length
+		help := sa.Help
+		if strings.EqualFold(sa.Name, l.defaultCommand) {
+			help += " (default)"
 		}
-	}
 
-	if l.ShowVersion {
-		l.PrintVersion()
+		header := strings.ToLower(strings.Join(sa.names(), ", "))
+		data.Commands = append(data.Commands, UsageEntry{Header: header, Help: help})
 	}
-
-	if l.ShowDesc {
-		fmt.Println(l.Description + "\n")
+	alignEntries(spacing, append(groupFlags(data.Groups), data.Flags)...)
+	if l.helpCommand() {
+		data.Commands = append(data.Commands, UsageEntry{Header: "help", Help: "display help for a command"})
 	}
+	alignEntries(spacing, data.Commands)
 
-	// Print usage line
-	fmt.Printf("Usage: %s", os.Args[0])
+	// Build usage line
+	var items []string
 	for _, f := range l.defaultArgument.FlagFacts {
-		if f.Type == FactTypeBool {
-			fmt.Printf(" [--%s]", f.Name)
-		} else {
-			fmt.Printf(" [--%s VALUE]", f.Name)
+		if !f.Hidden {
+			items = append(items, f.usageSummary())
 		}
 	}
-	fmt.Println(" COMMAND")
-	fmt.Println()
-
-	// Print flags
-	fmt.Println("Flags:")
-	for _, f := range factBank {
-		header := "  " + f.usageHeader()
-		extra := flagWidth - len(header)
-		space := strings.Repeat(" ", spacing+extra)
-		fmt.Println(header + space + f.Help)
+	prefix := "Usage: " + os.Args[0]
+	if l.commandSuffix != "" {
+		prefix += " " + l.commandSuffix
 	}
-	fmt.Println()
-
-	// Display sub-commands
-	fmt.Println("Commands:")
-	for _, sa := range l.SubArguments {
-		n := strings.ToLower(sa.Name)
-		header := "  " + n
-		extra := cmdWidth - len(header)
-		space := strings.Repeat(" ", spacing+extra)
-		fmt.Println(header + space + sa.Help)
+	if l.defaultCommand != "" || l.handler != nil || l.ctxHandler != nil {
+		items = append(items, "[COMMAND]")
+	} else {
+		items = append(items, "COMMAND")
 	}
+	data.Usage = usageLine(prefix, items, width)
+
+	return data
+}
 
-	fmt.Println()
-	fmt.Printf("Run '%s <command> --help' for details about a command.\n", binaryName())
+// UsageString returns the usage information of the
+// received Lawyer as a string.
+func (l Lawyer) UsageString() string {
+	var b strings.Builder
+	l.WriteUsage(&b)
+	return b.String()
 }
 
 // PrintVersion prints the version specified by the
-// Lawyer.
+// Lawyer to its output writer.
 func (l Lawyer) PrintVersion() {
-	fmt.Println(binaryName() + " " + l.Version)
+	l.WriteVersion(l.outputWriter())
+}
+
+// WriteVersion writes the version of the program
+// specified by the received Lawyer to the passed
+// writer.
+func (l Lawyer) WriteVersion(out io.Writer) {
+	fmt.Fprintln(out, binaryName()+" "+l.Version)
 }
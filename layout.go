@@ -0,0 +1,112 @@
+package argue
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// minWrapWidth is the narrowest space that text will
+// be wrapped into. Narrower text is left unwrapped.
+const minWrapWidth = 20
+
+// layout writes aligned and wrapped rows of usage
+// information.
+type layout struct {
+	out    io.Writer
+	column int
+	width  int
+}
+
+// row writes the passed header followed by the
+// passed help text at the layout's column. The help
+// text is wrapped to fit within the layout's width,
+// with each continued line starting at the column.
+func (l layout) row(header string, help string) {
+	space := l.column - len(header)
+	if space < 1 {
+		space = 1
+	}
+
+	lines := wrapText(help, l.width-l.column)
+	fmt.Fprintln(l.out, header+strings.Repeat(" ", space)+lines[0])
+	for _, line := range lines[1:] {
+		fmt.Fprintln(l.out, strings.Repeat(" ", l.column)+line)
+	}
+}
+
+// usageLine writes the passed prefix followed by the
+// passed items, wrapping them to fit within the
+// layout's width. Continued lines are indented to
+// line up with the first item.
+func (l layout) usageLine(prefix string, items []string) {
+	indent := len(prefix) + 1
+	if l.width-indent < minWrapWidth {
+		indent = len("Usage: ")
+	}
+
+	line := prefix
+	for _, item := range items {
+		if l.width > 0 && len(line) > indent && len(line)+1+len(item) > l.width {
+			fmt.Fprintln(l.out, line)
+			line = strings.Repeat(" ", indent-1)
+		}
+
+		line += " " + item
+	}
+
+	fmt.Fprintln(l.out, line)
+}
+
+// wrapText breaks the passed text into lines no
+// longer than the passed width where possible. If
+// the width is too narrow, the text is returned as a
+// single line.
+func wrapText(text string, width int) []string {
+	if width < minWrapWidth || len(text) <= width {
+		return []string{text}
+	}
+
+	var lines []string
+	var line string
+	for _, word := range strings.Fields(text) {
+		if line != "" && len(line)+1+len(word) > width {
+			lines = append(lines, line)
+			line = ""
+		}
+
+		if line != "" {
+			line += " "
+		}
+		line += word
+	}
+
+	return append(lines, line)
+}
+
+// outputWidth returns the width that usage
+// information written to the passed writer should be
+// wrapped to. The width set on the console is used
+// first, followed by the COLUMNS environment
+// variable and the size of the terminal. If none are
+// available or the console's width is negative, 0 is
+// returned and nothing is wrapped.
+func (c console) outputWidth(out io.Writer) int {
+	if c.width < 0 {
+		return 0
+	} else if c.width > 0 {
+		return c.width
+	}
+
+	if n, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && n > 0 {
+		return n
+	}
+
+	if f, ok := out.(*os.File); ok {
+		return terminalWidth(f)
+	}
+
+	return 0
+}
@@ -0,0 +1,132 @@
+package argue
+
+import "errors"
+
+// ParseError describes a failure to parse
+// command-line arguments. It matches the sentinel
+// error that represents its kind when used with
+// errors.Is, so callers may continue to compare
+// against errors such as ErrUnknownFlag.
+type ParseError struct {
+	// Kind is the sentinel error that represents the
+	// failure, such as ErrWrongType or ErrUnknownFlag.
+	Kind error
+
+	// Fact is the fact that the failure concerns, or
+	// nil if it does not concern a defined fact.
+	Fact *Fact
+
+	// Token is the raw argument that caused the
+	// failure, or an empty string if the failure was
+	// not caused by a single argument.
+	Token string
+
+	// Index is the position of Token within the parsed
+	// arguments, or -1 if there is no such token.
+	Index int
+
+	// Err is the underlying error that caused the
+	// failure, such as a failed value conversion.
+	Err error
+
+	// Suggestions holds the closest matches to an
+	// unknown flag or command, if any were found.
+	Suggestions []string
+
+	msg string
+}
+
+// newParseError returns a new ParseError of the
+// passed kind with the message that describes it.
+func newParseError(kind error, msg string) *ParseError {
+	return &ParseError{Kind: kind, Index: -1, msg: msg}
+}
+
+// at sets the token of the received error to the
+// argument at the passed index.
+func (e *ParseError) at(arguments []string, i int) *ParseError {
+	if i >= 0 && i < len(arguments) {
+		e.Token = arguments[i]
+		e.Index = i
+	}
+
+	return e
+}
+
+// Error returns the message of the received error.
+func (e *ParseError) Error() string {
+	return "argue: " + e.msg
+}
+
+// Message returns the message of the received error
+// in the form printed by strict parsing.
+func (e *ParseError) Message() string {
+	return e.msg
+}
+
+// Is returns true if the passed error is the Kind of
+// the received error.
+func (e *ParseError) Is(target error) bool {
+	return target == e.Kind
+}
+
+// Unwrap returns the underlying error of the
+// received error.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// ParseErrors returns every *ParseError within the
+// passed error, which may have been joined together
+// when collecting errors.
+func ParseErrors(err error) []*ParseError {
+	if perr, ok := err.(*ParseError); ok {
+		return []*ParseError{perr}
+	}
+
+	var perrs []*ParseError
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, e := range joined.Unwrap() {
+			perrs = append(perrs, ParseErrors(e)...)
+		}
+	}
+
+	return perrs
+}
+
+// joinParseErrors returns the only error passed, or
+// all of the passed errors joined together.
+func joinParseErrors(perrs []*ParseError) error {
+	if len(perrs) == 1 {
+		return perrs[0]
+	}
+
+	errs := make([]error, len(perrs))
+	for i, perr := range perrs {
+		errs[i] = perr
+	}
+
+	return errors.Join(errs...)
+}
+
+// errorMessages returns the message of each of the
+// passed errors.
+func errorMessages(perrs []*ParseError) []string {
+	msgs := make([]string, len(perrs))
+	for i, perr := range perrs {
+		msgs[i] = perr.Message()
+	}
+
+	return msgs
+}
+
+// shiftIndex moves the index of every *ParseError
+// with an index within the passed error by the
+// passed offset.
+func shiftIndex(err error, offset int) {
+	for _, perr := range ParseErrors(err) {
+		if perr.Index >= 0 {
+			perr.Index += offset
+		}
+	}
+}
@@ -1,13 +1,34 @@
 package argue
 
+import (
+	"context"
+	"strings"
+)
+
 // SubArgument represents one argument in a pool of
 // arguments, typically managed by a Lawyer.
 type SubArgument struct {
 	Name     string
 	Help     string
+	Aliases  []string
 	Argument Argument
 
-	handler func(*Lawyer, interface{})
+	// Lawyer, if set, is a nested Lawyer that takes
+	// the case of the arguments that follow the
+	// command, in place of Argument.
+	Lawyer *Lawyer
+
+	// Hidden, if true, leaves the command out of the
+	// Lawyer's usage information and suggestions.
+	Hidden bool
+
+	// Deprecated, if set, is printed in a warning
+	// whenever the command is used.
+	Deprecated string
+
+	handler    func(*Lawyer, interface{})
+	ctxHandler func(context.Context, *Lawyer, interface{}) error
+	owner      *Lawyer
 }
 
 // SetHandler sets the Handler field of SubArgument
@@ -19,3 +40,69 @@ type SubArgument struct {
 func (sa *SubArgument) SetHandler(f func(*Lawyer, interface{})) {
 	sa.handler = f
 }
+
+// AddAlias accepts alternative names that the
+// received SubArgument may be called by, such as
+// "rm" for "remove". This will panic if a name is
+// already used by the SubArgument or, if it was
+// added to a Lawyer, any of the Lawyer's
+// sub-arguments.
+func (sa *SubArgument) AddAlias(aliases ...string) *SubArgument {
+	for _, a := range aliases {
+		if sa.nameExists(a) {
+			panic("argue: this name already exists as a sub-argument")
+		}
+
+		sa.Aliases = append(sa.Aliases, a)
+	}
+
+	return sa
+}
+
+// nameExists returns true if the passed name is used
+// by the received SubArgument or by the Lawyer that
+// it was added to.
+func (sa SubArgument) nameExists(n string) bool {
+	if sa.owner != nil {
+		return sa.owner.NameExists(n)
+	}
+
+	for _, n2 := range sa.names() {
+		if strings.EqualFold(strings.TrimSpace(n), n2) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// names returns the name of the received
+// SubArgument followed by its aliases.
+func (sa SubArgument) names() []string {
+	return append([]string{sa.Name}, sa.Aliases...)
+}
+
+// SetContextHandler sets a function to call in place
+// of the handler set with SetHandler. It receives the
+// context passed to TakeCustomCaseContext along with
+// the same values as SetHandler, and any error that
+// it returns is passed on to the caller.
+func (sa *SubArgument) SetContextHandler(f func(context.Context, *Lawyer, interface{}) error) {
+	sa.ctxHandler = f
+}
+
+// SetHidden accepts a bool and sets the Hidden
+// property of the received SubArgument to that bool.
+func (sa *SubArgument) SetHidden(h bool) *SubArgument {
+	sa.Hidden = h
+	return sa
+}
+
+// SetDeprecated accepts a message, such as the
+// command to use instead, and marks the received
+// SubArgument as deprecated. An empty message
+// removes the deprecation.
+func (sa *SubArgument) SetDeprecated(msg string) *SubArgument {
+	sa.Deprecated = msg
+	return sa
+}
@@ -0,0 +1,98 @@
+package argue
+
+import (
+	"sort"
+	"strings"
+)
+
+// editDistance returns the number of insertions,
+// deletions, substitutions and transpositions of
+// adjacent characters needed to turn one of the
+// passed strings into the other.
+func editDistance(a string, b string) int {
+	prev2 := make([]int, len(b)+1)
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			curr[j] = minInt(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+
+			// Swapped neighbours count as a single edit
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				curr[j] = minInt(curr[j], prev2[j-2]+1)
+			}
+		}
+
+		prev2, prev, curr = prev, curr, prev2
+	}
+
+	return prev[len(b)]
+}
+
+func minInt(n int, others ...int) int {
+	for _, o := range others {
+		if o < n {
+			n = o
+		}
+	}
+
+	return n
+}
+
+// suggest returns the candidates that are closest to
+// the passed input, provided that they are close
+// enough to be a likely typo. Candidates that start
+// with the input are always suggested.
+func suggest(input string, candidates []string) []string {
+	input = strings.ToLower(input)
+	limit := len(input) / 3
+	if limit < 1 {
+		limit = 1
+	}
+
+	best := limit
+	var matches []string
+	for _, c := range candidates {
+		d := editDistance(input, strings.ToLower(c))
+		if len(input) > 2 && strings.HasPrefix(strings.ToLower(c), input) {
+			d = 1
+		}
+
+		if d < best {
+			best = d
+			matches = nil
+		}
+
+		if d == best {
+			matches = append(matches, c)
+		}
+	}
+
+	sort.Strings(matches)
+	return matches
+}
+
+// suggestionMessage returns the passed suggestions as
+// a phrase to append to an error message, or an
+// empty string if there are none.
+func suggestionMessage(suggestions []string) string {
+	switch len(suggestions) {
+	case 0:
+		return ""
+	case 1:
+		return ", did you mean " + suggestions[0] + "?"
+	}
+
+	last := len(suggestions) - 1
+	return ", did you mean " + strings.Join(suggestions[:last], ", ") + " or " + suggestions[last] + "?"
+}
@@ -0,0 +1,85 @@
+package argue
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEditDistance(t *testing.T) {
+	d := editDistance("kitten", "sitting")
+	if d != 3 {
+		t.Errorf("editDistance was incorrect, got: %d, expected: %d", d, 3)
+	}
+
+	d = editDistance("prot", "port")
+	if d != 1 {
+		t.Errorf("editDistance was incorrect, got: %d, expected: %d", d, 1)
+	}
+}
+
+func TestSuggestions(t *testing.T) {
+	var output string
+	var verbose bool
+	agmt := NewEmptyArgument()
+	agmt.AddFlagFact("output", "where to write", &output)
+	agmt.AddFlagFact("verbose", "say more", &verbose)
+
+	var perr *ParseError
+	err := agmt.DisputeCustom([]string{"--outptu", "x"}, false)
+	if !errors.As(err, &perr) || len(perr.Suggestions) != 1 || perr.Suggestions[0] != "--output" {
+		t.Errorf("DisputeCustom was incorrect, expected a suggestion of --output, got %v", err)
+	}
+
+	if perr.Message() != "unknown flag --outptu provided, did you mean --output?" {
+		t.Errorf("Message was incorrect, got: %s", perr.Message())
+	}
+
+	err = agmt.DisputeCustom([]string{"-V"}, false)
+	if !errors.As(err, &perr) || len(perr.Suggestions) != 1 || perr.Suggestions[0] != "-v" {
+		t.Errorf("DisputeCustom was incorrect, expected a suggestion of -v, got %v", err)
+	}
+
+	var path string
+	agmt.AddFlagFact("path", "where to look", &path).SetInitial('a')
+	err = agmt.DisputeCustom([]string{"-vp"}, false)
+	if !errors.As(err, &perr) || perr.Token != "-vp" || len(perr.Suggestions) != 1 || perr.Suggestions[0] != "--path" {
+		t.Errorf("DisputeCustom was incorrect, expected a suggestion of --path, got %v", err)
+	}
+
+	var host string
+	hosted := NewEmptyArgument()
+	hosted.AddFlagFact("host", "where to connect", &host)
+	err = hosted.DisputeCustom([]string{"--port"}, false)
+	if !errors.As(err, &perr) || len(perr.Suggestions) != 0 {
+		t.Errorf("DisputeCustom was incorrect, expected no suggestions, got %v", err)
+	}
+
+	err = hosted.DisputeCustom([]string{"--hsot"}, false)
+	if !errors.As(err, &perr) || len(perr.Suggestions) != 1 || perr.Suggestions[0] != "--host" {
+		t.Errorf("DisputeCustom was incorrect, expected a suggestion of --host, got %v", err)
+	}
+
+	short := NewEmptyLawyer()
+	short.SetHelpCommand(false)
+	short.AddArgument("rm", "remove an item", NewEmptyArgument())
+	short.AddArgument("ls", "list the items", NewEmptyArgument())
+	err = short.TakeCustomCase([]string{"cd"}, false)
+	if !errors.As(err, &perr) || len(perr.Suggestions) != 0 {
+		t.Errorf("TakeCustomCase was incorrect, expected no suggestions, got %v", err)
+	}
+
+	law := NewEmptyLawyer()
+	law.AddArgument("deploy", "deploy the app", NewEmptyArgument())
+	law.AddArgument("destroy", "destroy the app", NewEmptyArgument())
+	law.AddArgument("status", "show the status", NewEmptyArgument())
+
+	err = law.TakeCustomCase([]string{"dep"}, false)
+	if !errors.As(err, &perr) || !errors.Is(err, ErrUnknownCommand) || len(perr.Suggestions) != 1 || perr.Suggestions[0] != "deploy" {
+		t.Errorf("TakeCustomCase was incorrect, expected a suggestion of deploy, got %v", err)
+	}
+
+	err = law.TakeCustomCase([]string{"destory"}, false)
+	if !errors.As(err, &perr) || len(perr.Suggestions) != 1 || perr.Suggestions[0] != "destroy" {
+		t.Errorf("TakeCustomCase was incorrect, expected a suggestion of destroy, got %v", err)
+	}
+}
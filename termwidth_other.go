@@ -0,0 +1,11 @@
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd
+
+package argue
+
+import "os"
+
+// terminalWidth returns 0 as the size of terminals
+// can not be detected on this platform.
+func terminalWidth(f *os.File) int {
+	return 0
+}
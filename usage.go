@@ -0,0 +1,206 @@
+package argue
+
+import (
+	"io"
+	"strings"
+	"text/template"
+)
+
+// DefaultUsageTemplate is the template used to
+// render the usage information of an Argument when
+// no other template has been set.
+const DefaultUsageTemplate = `{{if .ShowVersion}}{{.Name}} {{.Version}}
+{{end}}{{if .ShowDesc}}{{.Description}}
+
+{{end}}{{.Usage}}
+{{if .Positionals}}
+Positional arguments:
+{{range .Positionals}}{{$.Row .}}{{end}}{{end}}
+Flags:
+{{range .Flags}}{{$.Row .}}{{end}}{{range .Groups}}
+{{.Title}}:
+{{range .Flags}}{{$.Row .}}{{end}}{{end}}`
+
+// DefaultLawyerUsageTemplate is the template used to
+// render the usage information of a Lawyer when no
+// other template has been set.
+const DefaultLawyerUsageTemplate = `{{if .ShowVersion}}{{.Name}} {{.Version}}
+{{end}}{{if .ShowDesc}}{{.Description}}
+
+{{end}}{{.Usage}}
+
+Flags:
+{{range .Flags}}{{$.Row .}}{{end}}{{range .Groups}}
+{{.Title}}:
+{{range .Flags}}{{$.Row .}}{{end}}{{end}}
+Commands:
+{{range .Commands}}{{$.Row .}}{{end}}
+Run '{{.Name}}{{with .Command}} {{.}}{{end}} <command> --help' for details about a command.
+`
+
+var (
+	defaultUsageTemplate       = template.Must(template.New("usage").Parse(DefaultUsageTemplate))
+	defaultLawyerUsageTemplate = template.Must(template.New("usage").Parse(DefaultLawyerUsageTemplate))
+)
+
+// UsageData holds the information that is available
+// to usage templates.
+type UsageData struct {
+	// Name is the name of the program's binary.
+	Name string
+
+	// Binary is the program as it was invoked, which
+	// is os.Args[0].
+	Binary string
+
+	// Command is the sub-command that the usage
+	// information describes, or an empty string for
+	// the top level of the program.
+	Command string
+
+	Version     string
+	ShowVersion bool
+	Description string
+	ShowDesc    bool
+
+	// Usage is the complete "Usage:" line, wrapped to
+	// Width.
+	Usage string
+
+	// Positionals holds the positional facts in the
+	// order that they are expected.
+	Positionals []UsageEntry
+
+	// Flags holds the flag facts that do not belong to
+	// a group, followed by the help and version flags.
+	Flags []UsageEntry
+
+	// Groups holds the groups of flag facts in the
+	// order that they should be displayed.
+	Groups []UsageGroup
+
+	// Commands holds the sub-commands of a Lawyer.
+	Commands []UsageEntry
+
+	// Width is the number of columns that output is
+	// wrapped to, or 0 if it is not wrapped.
+	Width int
+}
+
+// UsageEntry describes a single fact or sub-command
+// in usage information.
+type UsageEntry struct {
+	// Header is the name of the entry as it is shown
+	// in its list, such as "-n, --name VALUE".
+	Header string
+
+	// Help is the description of the entry, including
+	// its default value and environment variable.
+	Help string
+
+	// Fact is the fact that the entry describes, or
+	// nil if it describes a sub-command.
+	Fact *Fact
+
+	column int
+}
+
+// UsageGroup describes a titled section of flag
+// facts in usage information.
+type UsageGroup struct {
+	Title string
+	Flags []UsageEntry
+}
+
+// Row returns the passed entry as an indented line
+// with its help text aligned to the other entries of
+// its kind and wrapped to the width of the received
+// data.
+func (d UsageData) Row(e UsageEntry) string {
+	var b strings.Builder
+	layout{out: &b, column: e.column, width: d.Width}.row("  "+e.Header, e.Help)
+	return b.String()
+}
+
+// alignEntries sets the column of each of the passed
+// entries so that their help text starts at the same
+// position, the passed spacing past the longest
+// header.
+func alignEntries(spacing int, entries ...[]UsageEntry) {
+	width := 0
+	for _, es := range entries {
+		for _, e := range es {
+			if l := len(e.Header) + 2; l > width {
+				width = l
+			}
+		}
+	}
+
+	for _, es := range entries {
+		for i := range es {
+			es[i].column = width + spacing
+		}
+	}
+}
+
+// factEntries returns a usage entry for each of the
+// passed facts. The env function returns the
+// environment variable of a fact.
+func factEntries(facts []*Fact, env func(*Fact) string) []UsageEntry {
+	var entries []UsageEntry
+	for _, f := range facts {
+		entries = append(entries, UsageEntry{Header: f.usageHeader(), Help: f.usageHelp(env(f)), Fact: f})
+	}
+
+	return entries
+}
+
+// groupEntries returns a usage group for each of the
+// passed groups of facts. The env function returns
+// the environment variable of a fact.
+func groupEntries(groups []factGroup, env func(*Fact) string) []UsageGroup {
+	var entries []UsageGroup
+	for _, g := range groups {
+		entries = append(entries, UsageGroup{Title: g.title, Flags: factEntries(g.facts, env)})
+	}
+
+	return entries
+}
+
+// groupFlags returns the entries of each of the
+// passed groups.
+func groupFlags(groups []UsageGroup) [][]UsageEntry {
+	var entries [][]UsageEntry
+	for _, g := range groups {
+		entries = append(entries, g.Flags)
+	}
+
+	return entries
+}
+
+// usageLine returns the passed prefix followed by the
+// passed items, wrapped to the passed width.
+func usageLine(prefix string, items []string, width int) string {
+	var b strings.Builder
+	layout{out: &b, width: width}.usageLine(prefix, items)
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// parseUsageTemplate parses the passed text as a
+// usage template, panicking if it is invalid.
+func parseUsageTemplate(text string) *template.Template {
+	tmpl, err := template.New("usage").Parse(text)
+	if err != nil {
+		panic("argue: invalid usage template: " + err.Error())
+	}
+
+	return tmpl
+}
+
+// executeUsageTemplate renders the passed data to the
+// passed writer with the passed template.
+func executeUsageTemplate(out io.Writer, tmpl *template.Template, data UsageData) {
+	if err := tmpl.Execute(out, data); err != nil {
+		panic("argue: usage template failed: " + err.Error())
+	}
+}